@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "subcommand for local backup",
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list backups",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backups, err := util.ListBackups()
+		if err != nil {
+			return err
+		}
+		fmt.Println(util.BackupTableString(backups))
+		return nil
+	},
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "take a backup now",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if err = client.FullSync(context.Background(), []todoist.Command{}); err != nil {
+			return err
+		}
+		backup, err := util.WriteBackup(client.Snapshot())
+		if err != nil {
+			return err
+		}
+		fmt.Println(util.BackupTableString([]util.Backup{*backup}))
+		return nil
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore [name]",
+	Short: "re-create projects, labels and items of the backup which no longer exist",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("require a backup name to restore")
+		}
+		backup, err := util.ReadBackup(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+			return err
+		}
+		restore := util.NewBackupRestore(client, backup)
+		if restore.IsEmpty() {
+			fmt.Println("nothing to restore")
+			return nil
+		}
+		if n := len(restore.Projects); n > 0 {
+			fmt.Println(util.ProjectTableString(restore.Projects[:util.BulkPreview(n)]))
+			if rest := n - util.BulkPreview(n); rest > 0 {
+				fmt.Printf("... and %d more project(s)\n", rest)
+			}
+		}
+		if n := len(restore.Labels); n > 0 {
+			fmt.Println(util.LabelTableString(restore.Labels[:util.BulkPreview(n)]))
+			if rest := n - util.BulkPreview(n); rest > 0 {
+				fmt.Printf("... and %d more label(s)\n", rest)
+			}
+		}
+		if len(restore.Items) > 0 {
+			relations := todoist.ItemRelations{Projects: map[todoist.ID]todoist.Project{}, Labels: map[todoist.ID]todoist.Label{}}
+			for _, p := range backup.Projects {
+				relations.Projects[p.ID] = p
+			}
+			for _, l := range backup.Labels {
+				relations.Labels[l.ID] = l
+			}
+			util.PrintBulkItems(restore.Items, relations)
+		}
+		what := fmt.Sprintf("%d project(s), %d label(s) and %d item(s)", len(restore.Projects), len(restore.Labels), len(restore.Items))
+		if !util.ConfirmBulkCount("restore", what, len(restore.Projects)+len(restore.Labels)+len(restore.Items)) {
+			fmt.Println("abort")
+			return nil
+		}
+		if err = restore.Queue(client); err != nil {
+			return err
+		}
+		if err = client.Commit(ctx); err != nil {
+			return err
+		}
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+			return err
+		}
+		fmt.Println("succeeded to restore the backup")
+		return nil
+	},
+}
+
+var backupDiffCmd = &cobra.Command{
+	Use:   "diff [name]",
+	Short: "show what has changed since the backup",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("require a backup name to compare")
+		}
+		backup, err := util.ReadBackup(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if err = client.FullSync(context.Background(), []todoist.Command{}); err != nil {
+			return err
+		}
+		changes := todoist.DiffStates(*backup, client.Snapshot())
+		if changes.IsEmpty() && util.OutputFormat == "table" {
+			fmt.Println("no changes")
+			return nil
+		}
+		return util.Print(util.ChangesOutput(changes))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	backupCmd.AddCommand(backupDiffCmd)
+}
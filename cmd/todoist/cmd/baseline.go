@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// baselineCmd represents the baseline command
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "subcommand for baselines of weekly planning",
+	Long: `Save the state as a named baseline, such as monday, and later show
+the items added, completed, rescheduled and removed since then.`,
+}
+
+var baselineSaveCmd = &cobra.Command{
+	Use:   "save NAME",
+	Short: "save the state as the baseline of the name",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if err = client.FullSync(context.Background(), []todoist.Command{}); err != nil {
+			return err
+		}
+		b, err := util.SaveBaseline(args[0], client.Snapshot())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("saved baseline %s of %d item(s)\n", b.Name, len(b.State.Items))
+		return nil
+	},
+}
+
+var baselineDiffCmd = &cobra.Command{
+	Use:   "diff NAME",
+	Short: "show the items changed since the baseline of the name",
+	Long: `Show the items changed since the baseline of the name, marked by
++ added, x completed, > rescheduled and - removed.
+
+Items which have left the state are completed when the completed archive has them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, err := util.ReadBaseline(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+			return err
+		}
+		completed := map[todoist.ID]bool{}
+		archive, err := client.Completed.GetAllPages(ctx, &todoist.CompletedGetAllOpts{
+			Since: todoist.Time{Time: base.Time},
+		})
+		if err == nil {
+			for _, i := range archive.Items {
+				completed[i.TaskID] = true
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "completed archive is not available, items which left are removed: %s\n", err)
+		}
+		current := client.Snapshot()
+		d := util.NewBaselineDiff(base.State, current, completed)
+		if d.IsEmpty() && util.OutputFormat == "table" {
+			fmt.Printf("no changes since %s\n", base.Time.Format("2006-01-02 15:04"))
+			return nil
+		}
+		relations := client.Relation.Items(current.Items)
+		for _, p := range base.State.Projects {
+			if _, ok := relations.Projects[p.ID]; !ok {
+				relations.Projects[p.ID] = p
+			}
+		}
+		return util.Print(util.BaselineDiffOutput(d, relations))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(baselineCmd)
+	baselineCmd.AddCommand(baselineSaveCmd)
+	baselineCmd.AddCommand(baselineDiffCmd)
+}
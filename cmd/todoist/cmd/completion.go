@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"os"
+	"strings"
 )
 
 const (
@@ -58,7 +60,7 @@ __todoist_custom_func() {
 			__todoist_label_id
 			return
 			;;
-		todoist_project_update | todoist_project_delete | todoist_project_archive | todoist_project_unarchive)
+		todoist_project_update | todoist_project_delete | todoist_project_archive | todoist_project_unarchive | todoist_project_show | todoist_project_note_list | todoist_project_note_add)
 			__todoist_project_id
 			return
 			;;
@@ -239,8 +241,77 @@ _complete todoist 2>/dev/null
 	},
 }
 
+// cobra in use has no generator for powershell,
+// so the script completes commands and flags from tables built by walking the commands.
+var completionPowershellCmd = &cobra.Command{
+	Use:   "powershell",
+	Short: "generate powershell completion script",
+	Run: func(cmd *cobra.Command, args []string) {
+		var commands, flags []string
+		var walk func(c *cobra.Command)
+		walk = func(c *cobra.Command) {
+			var names []string
+			c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+				names = append(names, "'--"+f.Name+"'")
+			})
+			c.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+				names = append(names, "'--"+f.Name+"'")
+			})
+			flags = append(flags, fmt.Sprintf("        '%s' = @(%s)", c.CommandPath(), strings.Join(names, ", ")))
+			var subs []*cobra.Command
+			var quoted []string
+			for _, sub := range c.Commands() {
+				if sub.IsAvailableCommand() {
+					subs = append(subs, sub)
+					quoted = append(quoted, "'"+sub.Name()+"'")
+				}
+			}
+			if len(subs) > 0 {
+				commands = append(commands, fmt.Sprintf("        '%s' = @(%s)", c.CommandPath(), strings.Join(quoted, ", ")))
+			}
+			for _, sub := range subs {
+				walk(sub)
+			}
+		}
+		walk(RootCmd)
+		fmt.Printf(powershellCompletion, strings.Join(commands, "\n"), strings.Join(flags, "\n"))
+	},
+}
+
+const powershellCompletion = `Register-ArgumentCompleter -Native -CommandName todoist -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $commands = @{
+%s
+    }
+    $flags = @{
+%s
+    }
+    $path = @('todoist')
+    foreach ($element in $commandAst.CommandElements | Select-Object -Skip 1) {
+        $word = $element.ToString()
+        if ($word -eq $wordToComplete -or $word.StartsWith('-')) {
+            continue
+        }
+        $next = ($path + $word) -join ' '
+        if ($flags.ContainsKey($next)) {
+            $path += $word
+        }
+    }
+    $key = $path -join ' '
+    if ($wordToComplete.StartsWith('-')) {
+        $candidates = $flags[$key]
+    } else {
+        $candidates = $commands[$key]
+    }
+    $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`
+
 func init() {
 	RootCmd.AddCommand(completionCmd)
 	completionCmd.AddCommand(completionBashCmd)
 	completionCmd.AddCommand(completionZshCmd)
+	completionCmd.AddCommand(completionPowershellCmd)
 }
@@ -1,59 +1,188 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"io/ioutil"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 // configCmd represents the config command
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "configure about this CLI",
+	Long: `Configure the token and preferences of this CLI, then sync contents.
+
+Leave the token empty to sign in with the browser when TODOIST_CLIENT_ID and
+TODOIST_CLIENT_SECRET of your app are set. The redirect url of the app must be
+` + util.OAuthRedirectURL + `.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		dir := os.ExpandEnv("$HOME/.go-todoist")
-		if _, err := os.Stat(dir); err != nil {
-			if err = os.MkdirAll(dir, 0755); err != nil {
-				return err
-			}
-		}
-		var c util.Config
-		file := path.Join(dir, "config.json")
-		if b, err := ioutil.ReadFile(file); err != nil {
-			// initial config
-		} else {
-			// modify config
-			if err = json.Unmarshal(b, &c); err != nil {
-				return err
-			}
+		return runSetup()
+	},
+}
+
+// pendingExempt are the commands which do not offer the pending commands of the account.
+var pendingExempt = map[string]bool{
+	"config":   true,
+	"demo":     true,
+	"devtools": true,
+}
+
+// offersPending reports whether cmd offers to retry the commands of a failed commit first.
+// sync retries them by itself.
+func offersPending(cmd *cobra.Command) bool {
+	cmd = topCommand(cmd)
+	return cmd.HasParent() && !pendingExempt[cmd.Name()] && cmd != syncCmd
+}
+
+// topCommand returns the command under the root which cmd belongs to.
+func topCommand(cmd *cobra.Command) *cobra.Command {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return cmd
+}
+
+// runSetup asks the token and preferences, writes the config and runs an initial sync.
+func runSetup() error {
+	dir := todoist.DefaultDir()
+	if _, err := os.Stat(dir); err != nil {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return err
 		}
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Printf("todoist token (default: %s): ", c.Token)
-		if ans, err := reader.ReadString('\n'); err != nil {
+	}
+	var c util.Config
+	file := filepath.Join(dir, "config.json")
+	if b, err := ioutil.ReadFile(file); err != nil {
+		// initial config
+	} else {
+		// modify config
+		if err = json.Unmarshal(b, &c); err != nil {
 			return err
-		} else {
-			if ans != "\n" {
-				c.Token = strings.TrimSpace(ans)
-			}
 		}
-		if b, err := json.MarshalIndent(c, "", "  "); err != nil {
+	}
+	if len(c.Token) == 0 {
+		c.Token, _ = util.TokenFromKeyring()
+	}
+
+	clientID, clientSecret := viper.GetString("TODOIST_CLIENT_ID"), viper.GetString("TODOIST_CLIENT_SECRET")
+	oauth := len(clientID) != 0 && len(clientSecret) != 0
+	msg := fmt.Sprintf("todoist token (default: %s)", c.Token)
+	if len(c.Token) == 0 && oauth {
+		msg = "todoist token (default: sign in with the browser)"
+	}
+	ans, ok := util.Prompt(msg)
+	if !ok {
+		return errors.New("abort")
+	}
+	if ans = strings.TrimSpace(ans); len(ans) != 0 {
+		c.Token = ans
+	} else if len(c.Token) == 0 && oauth {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		token, err := util.OAuthToken(ctx, clientID, clientSecret)
+		if err != nil {
 			return err
-		} else {
-			if err = ioutil.WriteFile(file, b, 0644); err != nil {
-				return err
+		}
+		c.Token = token
+	}
+	if len(c.Token) == 0 {
+		return errors.New("require a token. the location of API token is Todoist > Settings > Integrations > API token")
+	}
+
+	for {
+		def := c.Timezone
+		if len(def) == 0 {
+			def = "local, " + time.Now().Format("MST -0700")
+		}
+		ans, _ := util.Prompt(fmt.Sprintf("timezone, e.g. Asia/Tokyo (default: %s)", def))
+		if ans = strings.TrimSpace(ans); len(ans) == 0 {
+			break
+		}
+		if _, err := time.LoadLocation(ans); err != nil {
+			fmt.Printf("unknown timezone: %s\n", ans)
+			continue
+		}
+		c.Timezone = ans
+		break
+	}
+	def := c.DefaultProject
+	if len(def) == 0 {
+		def = "Inbox"
+	}
+	if ans, _ := util.Prompt(fmt.Sprintf("default project of new items (default: %s)", def)); len(strings.TrimSpace(ans)) != 0 {
+		c.DefaultProject = strings.TrimSpace(ans)
+	}
+	c.Color = choose("color", []string{"auto", "always", "never"}, c.Color)
+	c.Output = choose("output format", []string{"table", "csv", "json", "vimgrep", "scriptfilter"}, c.Output)
+
+	token := c.Token
+	if err := util.StoreTokenInKeyring(c.Token); err == nil {
+		fmt.Println("write token to the keyring")
+		c.Token = ""
+	} else if err != util.ErrKeyringUnsupported {
+		return err
+	}
+	if err := util.WriteConfig(&c); err != nil {
+		return err
+	}
+	fmt.Printf("write config to %s\n", file)
+	if err := c.ApplyPreferences(false); err != nil {
+		return err
+	}
+
+	client, err := todoist.NewClient(viper.GetString("TODOIST_ENDPOINT"), token, "*", "", nil)
+	if err != nil {
+		return err
+	}
+	fmt.Print("syncing contents... ")
+	start := time.Now()
+	if err = client.FullSync(context.Background(), []todoist.Command{}); err != nil {
+		fmt.Println("failed")
+		return err
+	}
+	fmt.Printf("done in %s: %d projects, %d items, %d labels, %d filters\n",
+		time.Since(start).Round(time.Millisecond),
+		len(client.Project.GetAll()), len(client.Item.GetAll()), len(client.Label.GetAll()), len(client.Filter.GetAll()))
+	if len(c.DefaultProject) != 0 && client.Project.FindOneByName(c.DefaultProject) == nil {
+		fmt.Printf("warning: no such project: %s\n", c.DefaultProject)
+	}
+	if user := client.User.Get(); user != nil && len(user.Timezone) != 0 && len(c.Timezone) != 0 && user.Timezone != c.Timezone {
+		fmt.Printf("note: the timezone of your todoist account is %s\n", user.Timezone)
+	}
+	return nil
+}
+
+// choose asks one of the options until a valid answer. An empty answer keeps current,
+// or takes the first option.
+func choose(msg string, options []string, current string) string {
+	if len(current) == 0 {
+		current = options[0]
+	}
+	for {
+		ans, ok := util.Prompt(fmt.Sprintf("%s (%s, default: %s)", msg, strings.Join(options, ", "), current))
+		if ans = strings.TrimSpace(ans); !ok || len(ans) == 0 {
+			return current
+		}
+		for _, o := range options {
+			if ans == o {
+				return o
 			}
 		}
-		fmt.Printf("write config to %s\n", file)
-		return nil
-	},
+		fmt.Printf("choose one of %s\n", strings.Join(options, ", "))
+	}
 }
 
 func init() {
 	RootCmd.AddCommand(configCmd)
+	util.Setup = runSetup
 }
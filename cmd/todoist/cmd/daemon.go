@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// daemonCmd represents the daemon command
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "keep the cache synced and take scheduled backups",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		once, err := cmd.Flags().GetBool("once")
+		if err != nil {
+			return err
+		}
+		config, err := util.LoadConfig()
+		if err != nil {
+			return err
+		}
+		syncInterval, err := parseInterval(config.Daemon.SyncInterval, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+		if util.Metered.Enabled {
+			interval, err := util.Metered.Interval()
+			if err != nil {
+				return err
+			}
+			if interval > syncInterval {
+				syncInterval = interval
+			}
+		}
+		backupInterval, err := parseInterval(config.Daemon.Backup.Interval, 24*time.Hour)
+		if err != nil {
+			return err
+		}
+		retention := config.Daemon.Backup.Retention
+		if retention == 0 {
+			retention = 7
+		}
+
+		// leave the reserve of the rate limit budget to the commands
+		util.Background = true
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt)
+		ticker := time.NewTicker(syncInterval)
+		defer ticker.Stop()
+		for {
+			if err = runDaemon(client, config.Daemon.StaleInbox, backupInterval, retention); err != nil {
+				if once {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format(time.RFC3339), err)
+			}
+			if once {
+				return nil
+			}
+			select {
+			case <-ticker.C:
+			case <-sig:
+				return nil
+			}
+		}
+	},
+}
+
+func runDaemon(client *todoist.Client, stale util.StaleInboxConfig, backupInterval time.Duration, retention int) error {
+	ctx := context.Background()
+	if err := client.FullSync(ctx, []todoist.Command{}); err != nil {
+		return err
+	}
+	if stale.Days > 0 {
+		items, err := util.LabelStaleInbox(ctx, client, stale, time.Now())
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			fmt.Fprintf(os.Stderr, "%s: label %d inbox item(s) with @%s\n", time.Now().Format(time.RFC3339), len(items), stale.LabelName())
+		}
+	}
+	backups, err := util.ListBackups()
+	if err != nil {
+		return err
+	}
+	if len(backups) > 0 && time.Since(backups[len(backups)-1].Time) < backupInterval {
+		return nil
+	}
+	backup, err := util.WriteBackup(client.Snapshot())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s: backup %s\n", time.Now().Format(time.RFC3339), backup.Name)
+	return util.PruneBackups(retention)
+}
+
+func parseInterval(s string, def time.Duration) (time.Duration, error) {
+	if len(s) == 0 {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval: %s", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("interval must be positive: %s", s)
+	}
+	return d, nil
+}
+
+func init() {
+	daemonCmd.Flags().Bool("once", false, "sync and backup once, then exit")
+	RootCmd.AddCommand(daemonCmd)
+}
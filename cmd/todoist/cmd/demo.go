@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/kobtea/go-todoist/todoist/todoisttest"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// demoCmd represents the demo command
+var demoCmd = &cobra.Command{
+	Use:   "demo [command]",
+	Short: "run a command against a demo account without a token",
+	Long: `Run a command against a demo account without a token, e.g. todoist demo today.
+
+The account is served by a fake server in memory and starts from the same data
+on every run, so changes are thrown away when the command exits.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+			return cmd.Help()
+		}
+		if args[0] == cmd.Name() {
+			return errors.New("demo can not run demo")
+		}
+		now := time.Now()
+		server, err := todoisttest.NewServer(todoisttest.DemoState(now))
+		if err != nil {
+			return err
+		}
+		defer server.Close()
+		server.AddEvents(todoisttest.DemoEvents(now)...)
+		dir, err := ioutil.TempDir("", "todoist-demo")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		// the cache, config and token of the account are not touched
+		for k, v := range map[string]string{
+			"TODOIST_DIR":      dir,
+			"TODOIST_TOKEN":    "demo",
+			"TODOIST_ENDPOINT": server.Endpoint(),
+		} {
+			if err = os.Setenv(k, v); err != nil {
+				return err
+			}
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if err = client.FullSync(context.Background(), []todoist.Command{}); err != nil {
+			return err
+		}
+		// the command reports its errors by itself
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		RootCmd.SetArgs(args)
+		return RootCmd.Execute()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(demoCmd)
+}
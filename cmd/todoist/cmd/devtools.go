@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/kobtea/go-todoist/todoist/todoisttest"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// devtoolsCmd represents the devtools command
+var devtoolsCmd = &cobra.Command{
+	Use:   "devtools",
+	Short: "subcommand for development tools",
+}
+
+var devtoolsGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "populate an account with synthetic data",
+	Long: `Populate an account with synthetic data for benchmarks, e.g. todoist devtools generate --items 10000 --projects 50.
+
+A few projects and labels hold most of the items, and due dates, priorities,
+recurrences and sub tasks are spread like in real accounts. The same seed
+generates the same data.
+
+With --serve, the data is served by a fake server in memory until interrupted.
+Otherwise it is added to the account of the token, so use a sandbox account.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts todoisttest.GenerateOpts
+		var err error
+		if opts.Items, err = cmd.Flags().GetInt("items"); err != nil {
+			return err
+		}
+		if opts.Projects, err = cmd.Flags().GetInt("projects"); err != nil {
+			return err
+		}
+		if opts.Labels, err = cmd.Flags().GetInt("labels"); err != nil {
+			return err
+		}
+		if opts.Seed, err = cmd.Flags().GetInt64("seed"); err != nil {
+			return err
+		}
+		serve, err := cmd.Flags().GetBool("serve")
+		if err != nil {
+			return err
+		}
+		state := todoisttest.Generate(opts, time.Now())
+
+		if serve {
+			server, err := todoisttest.NewServer(state)
+			if err != nil {
+				return err
+			}
+			defer server.Close()
+			fmt.Printf("serving %d projects, %d items and %d labels at %s\n",
+				len(state.Projects), len(state.Items), len(state.Labels), server.Endpoint())
+			// the env is exported, as the cache has to be synced before the views read it
+			fmt.Printf("run commands with export TODOIST_ENDPOINT=%s TODOIST_TOKEN=generated TODOIST_DIR=$(mktemp -d); todoist sync && todoist today\n", server.Endpoint())
+			sig := make(chan os.Signal, 1)
+			signal.Notify(sig, os.Interrupt)
+			<-sig
+			return nil
+		}
+
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		inbox, err := util.FindInbox(client)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("this adds %d projects, %d sections, %d items and %d labels to the account of the token\n",
+			len(state.Projects)-1, len(state.Sections), len(state.Items), len(state.Labels))
+		if !util.Confirm("are you sure to populate it? use a sandbox account") {
+			fmt.Println("abort")
+			return nil
+		}
+		ops := util.GeneratedOperations(state, inbox.ID)
+		ctx := context.Background()
+		results, err := client.BatchExecute(ctx, ops, func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\radded %d/%d", done, total)
+		})
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, r := range results {
+			if r.Err != nil {
+				failed++
+			}
+		}
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to add %d of %d entities", failed, len(ops))
+		}
+		fmt.Printf("added %d entities\n", len(ops))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(devtoolsCmd)
+	devtoolsGenerateCmd.Flags().Int("items", 1000, "number of items")
+	devtoolsGenerateCmd.Flags().Int("projects", 10, "number of projects, including the inbox")
+	devtoolsGenerateCmd.Flags().Int("labels", 20, "number of labels")
+	devtoolsGenerateCmd.Flags().Int64("seed", 1, "seed of the random data")
+	devtoolsGenerateCmd.Flags().Bool("serve", false, "serve the data by a fake server instead of adding it to the account")
+	devtoolsCmd.AddCommand(devtoolsGenerateCmd)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"sort"
+	"strings"
+	"time"
+)
+
+// digestCmd represents the digest command
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "send weekly review as email",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stdout, err := cmd.Flags().GetBool("stdout")
+		if err != nil {
+			return err
+		}
+		html, err := cmd.Flags().GetBool("html")
+		if err != nil {
+			return err
+		}
+		emailTo, err := cmd.Flags().GetString("email-to")
+		if err != nil {
+			return err
+		}
+		addr, err := cmd.Flags().GetString("smtp")
+		if err != nil {
+			return err
+		}
+		if !stdout && len(emailTo) == 0 {
+			return errors.New("require --email-to or --stdout")
+		}
+
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		until := time.Now()
+		since := until.AddDate(0, 0, -7)
+		completed, err := client.Completed.GetAllPages(context.Background(), &todoist.CompletedGetAllOpts{
+			Since: todoist.Time{Time: since},
+			Until: todoist.Time{Time: until},
+		})
+		if err != nil {
+			return err
+		}
+		var overdue, upcoming []todoist.Item
+		for _, i := range client.Item.FindByDueDate(todoist.Next7Days()) {
+			if i.IsChecked() {
+				continue
+			}
+			if i.IsOverDueDate() {
+				overdue = append(overdue, i)
+			} else {
+				upcoming = append(upcoming, i)
+			}
+		}
+		for _, items := range [][]todoist.Item{overdue, upcoming} {
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Due.Date.Local().Before(items[j].Due.Date.Local())
+			})
+		}
+		relations := client.Relation.Items(append(overdue, upcoming...))
+		digest := util.NewDigest(since, until, completed, overdue, upcoming, relations)
+
+		if stdout {
+			var s string
+			if html {
+				s, err = digest.HTML()
+			} else {
+				s, err = digest.Text()
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Print(s)
+			return nil
+		}
+
+		config, err := util.LoadConfig()
+		if err != nil {
+			return err
+		}
+		to := strings.Split(emailTo, ",")
+		from := config.SMTP.From
+		if len(from) == 0 {
+			from = to[0]
+			config.SMTP.From = from
+		}
+		msg, err := digest.Message(from, to)
+		if err != nil {
+			return err
+		}
+		if err = util.SendMail(config.SMTP, addr, to, msg); err != nil {
+			return err
+		}
+		fmt.Printf("succeeded to send the digest to %s\n", emailTo)
+		return nil
+	},
+}
+
+func init() {
+	digestCmd.Flags().String("email-to", "", "recipient address(es) (delimiter: ,)")
+	digestCmd.Flags().String("smtp", "", "smtp server address (host:port), overrides config")
+	digestCmd.Flags().Bool("stdout", false, "print the digest instead of sending it")
+	digestCmd.Flags().Bool("html", false, "print html instead of text with --stdout")
+	RootCmd.AddCommand(digestCmd)
+}
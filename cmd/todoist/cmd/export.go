@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "subcommand for export",
+}
+
+var exportGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "export the structure of a project as a dot or mermaid graph",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectStr, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if len(projectStr) == 0 {
+			return errors.New("require --project")
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		projectID, err := util.ResolveProjectID(client, projectStr)
+		if err != nil {
+			return err
+		}
+		graph, err := util.NewGraph(client, projectID)
+		if err != nil {
+			return err
+		}
+		switch format {
+		case "dot":
+			fmt.Print(graph.DotString())
+		case "mermaid":
+			fmt.Print(graph.MermaidString())
+		default:
+			return fmt.Errorf("unsupported format: %s", format)
+		}
+		return nil
+	},
+}
+
+var exportTimeblocksCmd = &cobra.Command{
+	Use:   "timeblocks",
+	Short: "export the items of a day as a schedule of time blocks",
+	Long: `Export the items of a day as a schedule of time blocks.
+
+Items with a due time start at the time and last their duration, or --default-duration
+without one. Items of the day without a time but with a duration fill the first free
+gap from --start. Overlapping blocks are marked as conflicts and free time as gaps.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dateStr, err := cmd.Flags().GetString("date")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		startStr, err := cmd.Flags().GetString("start")
+		if err != nil {
+			return err
+		}
+		def, err := cmd.Flags().GetDuration("default-duration")
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		switch dateStr {
+		case "today":
+		case "tomorrow":
+			date = date.AddDate(0, 0, 1)
+		default:
+			if date, err = time.ParseInLocation("2006-01-02", dateStr, time.Local); err != nil {
+				return fmt.Errorf("invalid date: %s", dateStr)
+			}
+		}
+		clock, err := time.Parse("15:04", startStr)
+		if err != nil {
+			return fmt.Errorf("invalid start: %s", startStr)
+		}
+		start := date.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		schedule := util.NewSchedule(client.Item.GetAll(), date, start, def)
+		switch format {
+		case "table":
+			var items []todoist.Item
+			for _, b := range schedule.Blocks {
+				items = append(items, b.Item)
+			}
+			return util.Print(util.ScheduleOutput(schedule, client.Relation.Items(items)))
+		case "markdown":
+			fmt.Print(schedule.Markdown())
+		case "ics":
+			fmt.Print(schedule.ICS())
+		default:
+			return fmt.Errorf("unsupported format: %s", format)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	exportGraphCmd.Flags().StringP("project", "p", "", "project id or name")
+	exportGraphCmd.Flags().StringP("format", "f", "dot", "graph format (dot|mermaid)")
+	exportCmd.AddCommand(exportGraphCmd)
+	exportTimeblocksCmd.Flags().String("date", "today", "day to schedule (today|tomorrow|YYYY-MM-DD)")
+	exportTimeblocksCmd.Flags().StringP("format", "f", "table", "format of the schedule (table|markdown|ics)")
+	exportTimeblocksCmd.Flags().String("start", "09:00", "start of the day for items without a time")
+	exportTimeblocksCmd.Flags().Duration("default-duration", 30*time.Minute, "duration of items with a time but without a duration")
+	exportCmd.AddCommand(exportTimeblocksCmd)
+}
@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"github.com/kobtea/go-todoist/cmd/util"
 	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
-	"os"
 	"strings"
 )
 
@@ -27,8 +25,7 @@ var filterListCmd = &cobra.Command{
 			return err
 		}
 		filters := client.Filter.GetAll()
-		fmt.Println(util.FilterTableString(filters))
-		return nil
+		return util.Print(util.FiltersOutput(filters))
 	},
 }
 
@@ -191,10 +188,7 @@ var filterDeleteCmd = &cobra.Command{
 					return fmt.Errorf("invalid filter id: %s", id)
 				}
 				fmt.Println(util.FilterTableString([]todoist.Filter{*filter}))
-				reader := bufio.NewReader(os.Stdin)
-				fmt.Print("are you sure to delete above filter? (y/[n]): ")
-				ans, err := reader.ReadString('\n')
-				if ans != "y\n" || err != nil {
+				if !util.Confirm("are you sure to delete above filter?") {
 					fmt.Println("abort")
 					return errors.New("abort")
 				}
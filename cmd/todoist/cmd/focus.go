@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+// focusCmd represents the focus command
+var focusCmd = &cobra.Command{
+	Use:   "focus",
+	Short: "subcommand for the focus of lists",
+	Long: `Set a filter query as the focus, which narrows item list, inbox, today and next
+until it is cleared or expires. Pass --no-focus to see all items for a while.`,
+}
+
+var focusSetCmd = &cobra.Command{
+	Use:   "set QUERY",
+	Short: "set the focus",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d, err := cmd.Flags().GetDuration("for")
+		if err != nil {
+			return err
+		}
+		f, err := util.SetFocus(strings.Join(args, " "), d)
+		if err != nil {
+			return err
+		}
+		if f.Until.IsZero() {
+			fmt.Printf("focus on %s until cleared\n", f.Query)
+		} else {
+			fmt.Printf("focus on %s until %s\n", f.Query, f.Until.Format("15:04"))
+		}
+		return nil
+	},
+}
+
+var focusShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "show the focus",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := util.LoadFocus()
+		if err != nil {
+			return err
+		}
+		switch {
+		case f == nil:
+			fmt.Println("no focus")
+		case f.Until.IsZero():
+			fmt.Printf("%s (since %s)\n", f.Query, f.Since.Format("2006-01-02 15:04"))
+		default:
+			fmt.Printf("%s (since %s, until %s)\n", f.Query, f.Since.Format("2006-01-02 15:04"), f.Until.Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+var focusClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "clear the focus",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := util.ClearFocus(); err != nil {
+			return err
+		}
+		fmt.Println("cleared the focus")
+		return nil
+	},
+}
+
+func init() {
+	focusSetCmd.Flags().Duration("for", 0, "expire the focus after the duration, such as 2h")
+	focusCmd.AddCommand(focusSetCmd)
+	focusCmd.AddCommand(focusShowCmd)
+	focusCmd.AddCommand(focusClearCmd)
+	RootCmd.AddCommand(focusCmd)
+}
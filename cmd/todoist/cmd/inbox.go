@@ -2,11 +2,10 @@ package cmd
 
 import (
 	"fmt"
-
-	"errors"
 	"github.com/kobtea/go-todoist/cmd/util"
 	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 // inboxCmd represents the inbox command
@@ -14,22 +13,64 @@ var inboxCmd = &cobra.Command{
 	Use:   "inbox",
 	Short: "show inbox tasks",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		selectMode, err := cmd.Flags().GetBool("select")
+		if err != nil {
+			return err
+		}
 		client, err := util.NewClient()
 		if err != nil {
 			return err
 		}
-		projects := client.Project.FindByName("Inbox")
-		if len(projects) != 1 {
-			return errors.New("Failed to detect inbox. It may exist multiple inbox.")
+		inbox, err := util.FindInbox(client)
+		if err != nil {
+			return err
 		}
-		inbox := projects[0]
 		items := client.Item.FindByProjectIDs([]todoist.ID{inbox.ID})
+		if items, err = util.ApplyFocus(client, items); err != nil {
+			return err
+		}
 		relations := client.Relation.Items(items)
-		fmt.Println(util.ItemTableString(items, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+		if selectMode {
+			return runSelect(client, items, relations)
+		}
+		return util.Print(util.ItemsOutput(items, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+	},
+}
+
+var inboxPressureCmd = &cobra.Command{
+	Use:   "pressure",
+	Short: "show the age distribution of inbox tasks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, err := cmd.Flags().GetInt("stale-days")
+		if err != nil {
+			return err
+		}
+		// the rule of the config applies unless the flag is given
+		if config, err := util.LoadConfig(); err == nil && config.Daemon.StaleInbox.Days > 0 && !cmd.Flags().Changed("stale-days") {
+			days = config.Daemon.StaleInbox.Days
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		inbox, err := util.FindInbox(client)
+		if err != nil {
+			return err
+		}
+		pressure := util.NewInboxPressure(client.Item.FindByProjectIDs([]todoist.ID{inbox.ID}), days, time.Now())
+		if err = util.Print(util.InboxPressureOutput(pressure)); err != nil {
+			return err
+		}
+		if util.OutputFormat == "table" {
+			fmt.Println(pressure)
+		}
 		return nil
 	},
 }
 
 func init() {
+	inboxCmd.Flags().Bool("select", false, selectFlagUsage)
+	inboxPressureCmd.Flags().Int("stale-days", 14, "age of stale tasks in days, overrides the stale_inbox rule of the daemon")
+	inboxCmd.AddCommand(inboxPressureCmd)
 	RootCmd.AddCommand(inboxCmd)
 }
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -10,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -24,14 +24,43 @@ var itemListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "list items",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		selectMode, err := cmd.Flags().GetBool("select")
+		if err != nil {
+			return err
+		}
+		hasAttachment, err := cmd.Flags().GetBool("has-attachment")
+		if err != nil {
+			return err
+		}
+		attachmentType, err := cmd.Flags().GetString("type")
+		if err != nil {
+			return err
+		}
+		if len(attachmentType) != 0 {
+			if err = util.CheckAttachmentType(attachmentType); err != nil {
+				return err
+			}
+			hasAttachment = true
+		}
 		client, err := util.NewClient()
 		if err != nil {
 			return err
 		}
 		items := client.Item.GetAll()
+		if items, err = util.ApplyFocus(client, items); err != nil {
+			return err
+		}
+		if hasAttachment {
+			items = util.FilterItemsByAttachment(client, items, attachmentType)
+		}
 		relations := client.Relation.Items(items)
-		fmt.Println(util.ItemTableString(items, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
-		return nil
+		if selectMode {
+			return runSelect(client, items, relations)
+		}
+		if err = util.SaveItemAliases(items); err != nil {
+			return err
+		}
+		return util.Print(util.AliasedItemsOutput(items, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
 	},
 }
 
@@ -50,6 +79,9 @@ var itemAddCmd = &cobra.Command{
 		if err != nil {
 			return errors.New("invalid project id or name")
 		}
+		if config, err := util.LoadConfig(); err == nil && len(config.DefaultProject) != 0 && !cmd.Flags().Changed("project") {
+			projectIDorName = config.DefaultProject
+		}
 		if pid, err := todoist.NewID(projectIDorName); err != nil {
 			if project := client.Project.FindOneByName(projectIDorName); project != nil {
 				item.ProjectID = project.ID
@@ -79,7 +111,20 @@ var itemAddCmd = &cobra.Command{
 			return errors.New("invalid due date format")
 		}
 		if len(due) > 0 {
-			item.Due.String = due
+			if err = client.Item.SetDue(&item, due); err != nil {
+				return err
+			}
+		}
+		if pick, err := cmd.Flags().GetBool("pick-date"); err != nil {
+			return err
+		} else if pick {
+			date, err := util.PickDate("due date", time.Time{})
+			if err != nil {
+				return err
+			}
+			if !date.IsZero() {
+				util.SetDueDate(&item, date)
+			}
 		}
 
 		priority, err := cmd.Flags().GetInt("priority")
@@ -110,6 +155,7 @@ var itemAddCmd = &cobra.Command{
 		relations := client.Relation.Items([]todoist.Item{syncedItem})
 		fmt.Println("Successful addition of an item.")
 		fmt.Println(util.ItemTableString([]todoist.Item{syncedItem}, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+		util.AuditChange("added", syncedItem.ID)
 		return nil
 	},
 }
@@ -121,7 +167,7 @@ var itemUpdateCmd = &cobra.Command{
 		if len(args) < 1 {
 			return errors.New("require item id to update")
 		}
-		id, err := todoist.NewID(args[0])
+		id, err := util.ParseItemID(args[0])
 		if err != nil {
 			return fmt.Errorf("invalid id: %s", args[0])
 		}
@@ -133,6 +179,14 @@ var itemUpdateCmd = &cobra.Command{
 		if item == nil {
 			return fmt.Errorf("no such item id: %s", id)
 		}
+		before := *item
+		fields, err := cmd.Flags().GetStringSlice("fields")
+		if err != nil {
+			return err
+		}
+		if err = util.CheckItemDiffFields(fields); err != nil {
+			return err
+		}
 		if len(args) > 1 {
 			item.Content = strings.Join(args[1:], " ")
 		}
@@ -158,7 +212,21 @@ var itemUpdateCmd = &cobra.Command{
 			return errors.New("invalid due date format")
 		}
 		if len(due) > 0 {
-			item.Due.String = due
+			if err = client.Item.SetDue(item, due); err != nil {
+				return err
+			}
+		}
+		if pick, err := cmd.Flags().GetBool("pick-date"); err != nil {
+			return err
+		} else if pick {
+			date, err := util.PickDate("due date", item.Due.Date.Local().Time)
+			if err != nil {
+				return err
+			}
+			// the api has no way to remove the due by an update, so no date keeps it
+			if !date.IsZero() {
+				util.SetDueDate(item, date)
+			}
 		}
 
 		priority, err := cmd.Flags().GetInt("priority")
@@ -181,9 +249,14 @@ var itemUpdateCmd = &cobra.Command{
 		if syncedItem == nil {
 			return errors.New("failed to add this item. it may be failed to sync")
 		}
-		relations := client.Relation.Items([]todoist.Item{*syncedItem})
+		relations := client.Relation.Items([]todoist.Item{before, *syncedItem})
+		diff, err := util.ItemDiffString(before, *syncedItem, relations, fields)
+		if err != nil {
+			return err
+		}
 		fmt.Println("success to update the item")
-		fmt.Println(util.ItemTableString([]todoist.Item{*syncedItem}, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+		fmt.Println(diff)
+		util.AuditChange("updated", id)
 		return nil
 	},
 }
@@ -196,7 +269,7 @@ var itemDeleteCmd = &cobra.Command{
 			if len(args) != 1 {
 				return fmt.Errorf("require one item id")
 			}
-			id, err := todoist.NewID(args[0])
+			id, err := util.ParseItemID(args[0])
 			if err != nil {
 				return err
 			}
@@ -206,16 +279,13 @@ var itemDeleteCmd = &cobra.Command{
 			}
 			relations := client.Relation.Items([]todoist.Item{*item})
 			fmt.Println(util.ItemTableString([]todoist.Item{*item}, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
-			reader := bufio.NewReader(os.Stdin)
-			fmt.Print("are you sure to delete above item(s)? (y/[n]): ")
-			ans, err := reader.ReadString('\n')
-			if ans != "y\n" || err != nil {
+			if !util.Confirm("are you sure to delete above item(s)?") {
 				fmt.Println("abort")
-				return errors.New("abort")
+				return util.ErrAbort
 			}
 			return client.Item.Delete(id)
 		}); err != nil {
-			if err.Error() == "abort" {
+			if err == util.ErrAbort {
 				return nil
 			}
 			return err
@@ -226,42 +296,99 @@ var itemDeleteCmd = &cobra.Command{
 }
 
 var itemMoveCmd = &cobra.Command{
-	Use:   "move",
+	Use:   "move [id]",
 	Short: "move the project of the item",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := util.NewClient()
 		if err != nil {
 			return err
 		}
-		if len(args) < 1 {
-			return errors.New("Require item ID to move")
-		}
-		id, err := todoist.NewID(args[0])
+		filter, err := cmd.Flags().GetString("filter")
 		if err != nil {
-			return fmt.Errorf("Invalid ID: %s", args[0])
+			return err
 		}
-		item := client.Item.Resolve(id)
-		if item == nil {
-			return fmt.Errorf("No such item id: %s", id)
+		var items []todoist.Item
+		if len(filter) != 0 {
+			if len(args) != 0 {
+				return errors.New("require either item ID or filter")
+			}
+			matched, err := client.Item.FindByQuery(filter)
+			if err != nil {
+				return err
+			}
+			for _, i := range matched {
+				if !i.IsChecked() {
+					items = append(items, i)
+				}
+			}
+			if len(items) == 0 {
+				fmt.Println("no items match the filter")
+				return nil
+			}
+		} else {
+			if len(args) < 1 {
+				return errors.New("Require item ID to move")
+			}
+			id, err := util.ParseItemID(args[0])
+			if err != nil {
+				return fmt.Errorf("Invalid ID: %s", args[0])
+			}
+			item := client.Item.Resolve(id)
+			if item == nil {
+				return fmt.Errorf("No such item id: %s", id)
+			}
+			items = append(items, *item)
 		}
 
+		fields, err := cmd.Flags().GetStringSlice("fields")
+		if err != nil {
+			return err
+		}
+		if err = util.CheckItemDiffFields(fields); err != nil {
+			return err
+		}
 		opts := &todoist.ItemMoveOpts{}
-		if parentID, err := cmd.Flags().GetString("parent"); err == nil {
-			if id, err := todoist.NewID(parentID); err != nil {
+		if parentID, err := cmd.Flags().GetString("parent"); err == nil && len(parentID) != 0 {
+			if id, err := util.ParseItemID(parentID); err != nil {
 				return fmt.Errorf("invalid parent id: %s", parentID)
 			} else {
 				opts.ParentID = id
 			}
 		}
-		if projectID, err := cmd.Flags().GetString("project"); err == nil {
-			if id, err  := todoist.NewID(projectID); err != nil {
-				return fmt.Errorf("invalid project id: %s", projectID)
+		if project, err := cmd.Flags().GetString("project"); err == nil && len(project) != 0 {
+			if id, err := util.ResolveProjectID(client, project); err != nil {
+				return err
 			} else {
 				opts.ProjectID = id
 			}
 		}
-		if err = client.Item.Move(id, opts); err != nil {
-			return err
+		if section, err := cmd.Flags().GetString("section"); err == nil && len(section) != 0 {
+			projectID := opts.ProjectID
+			if projectID.IsZero() && len(items) == 1 {
+				projectID = items[0].ProjectID
+			}
+			if projectID.IsZero() {
+				return errors.New("require project to find the section")
+			}
+			if id, err := util.ResolveSectionID(client, projectID, section); err != nil {
+				return err
+			} else {
+				// the section determines the project
+				opts.SectionID = id
+				opts.ProjectID = ""
+			}
+		}
+
+		if len(filter) != 0 {
+			if !util.ConfirmBulk("move", items, client.Relation.Items(items)) {
+				fmt.Println("abort")
+				return nil
+			}
+		}
+		for _, i := range items {
+			if err = client.Item.Move(i.ID, opts); err != nil {
+				return err
+			}
 		}
 		ctx := context.Background()
 		if err = client.Commit(ctx); err != nil {
@@ -270,13 +397,35 @@ var itemMoveCmd = &cobra.Command{
 		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
 			return err
 		}
-		syncedItem := client.Item.Resolve(id)
-		if syncedItem == nil {
+		var syncedItems []todoist.Item
+		for _, i := range items {
+			if syncedItem := client.Item.Resolve(i.ID); syncedItem != nil {
+				syncedItems = append(syncedItems, *syncedItem)
+			}
+		}
+		if len(syncedItems) == 0 {
 			return errors.New("Failed to move this item. It may be failed to sync.")
 		}
-		relations := client.Relation.Items([]todoist.Item{*syncedItem})
+		relations := client.Relation.Items(append(items, syncedItems...))
 		fmt.Println("Successful move item.")
-		fmt.Println(util.ItemTableString([]todoist.Item{*syncedItem}, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+		for n, i := range items {
+			for _, syncedItem := range syncedItems {
+				if syncedItem.ID != i.ID {
+					continue
+				}
+				diff, err := util.ItemDiffString(i, syncedItem, relations, fields)
+				if err != nil {
+					return err
+				}
+				if len(items) > 1 {
+					if n > 0 {
+						fmt.Println()
+					}
+					fmt.Printf("%s %s\n", i.ID, i.Content)
+				}
+				fmt.Println(diff)
+			}
+		}
 		return nil
 	},
 }
@@ -285,21 +434,82 @@ var itemCompleteCmd = &cobra.Command{
 	Use:   "complete",
 	Short: "complete items",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		quickfix, err := cmd.Flags().GetString("from-quickfix")
+		if err != nil {
+			return err
+		}
+		yes, err := cmd.Flags().GetBool("yes")
+		if err != nil {
+			return err
+		}
+		// the answer can not be read from stdin after the list
+		if quickfix == "-" && !yes {
+			return errors.New("reading the quickfix list from stdin requires --yes")
+		}
+		var ids []todoist.ID
 		if err := util.AutoCommit(func(client todoist.Client, ctx context.Context) error {
-			if len(args) != 1 {
-				return fmt.Errorf("require one item id")
+			if len(quickfix) != 0 {
+				if len(args) != 0 {
+					return errors.New("require either item id or quickfix file")
+				}
+				r := os.Stdin
+				if quickfix != "-" {
+					f, err := os.Open(quickfix)
+					if err != nil {
+						return err
+					}
+					defer f.Close()
+					r = f
+				}
+				if ids, err = util.ParseQuickfix(r); err != nil {
+					return err
+				}
+				if len(ids) == 0 {
+					return errors.New("no items in the quickfix list")
+				}
+				if !yes {
+					var items []todoist.Item
+					for _, id := range ids {
+						if item := client.Item.Resolve(id); item != nil {
+							items = append(items, *item)
+						}
+					}
+					if !util.ConfirmBulk("complete", items, client.Relation.Items(items)) {
+						fmt.Println("abort")
+						return util.ErrAbort
+					}
+				}
+			} else {
+				if len(args) != 1 {
+					return fmt.Errorf("require one item id")
+				}
+				id, err := util.ParseItemID(args[0])
+				if err != nil {
+					return err
+				}
+				ids = append(ids, id)
 			}
-			id, err := todoist.NewID(args[0])
-			if err != nil {
-				return err
+			for _, id := range ids {
+				if item := client.Item.Resolve(id); item != nil {
+					if err = util.SavePosition(*item); err != nil {
+						return err
+					}
+				}
+				// FIXME: support date_completed option
+				date := todoist.Time{Time: time.Now().UTC()}
+				if err = client.Item.Complete(id, date, true); err != nil {
+					return err
+				}
 			}
-			// FIXME: support date_completed option
-			date := todoist.Time{time.Now().UTC()}
-			return client.Item.Complete(id, date, true)
+			return nil
 		}); err != nil {
+			if err == util.ErrAbort {
+				return nil
+			}
 			return err
 		}
 		fmt.Println("Successful completion of item(s).")
+		util.AuditChange("completed", ids...)
 		return nil
 	},
 }
@@ -308,44 +518,370 @@ var itemUncompleteCmd = &cobra.Command{
 	Use:   "uncomplete",
 	Short: "uncomplete items",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := util.AutoCommit(func(client todoist.Client, ctx context.Context) error {
-			if len(args) != 1 {
-				return fmt.Errorf("require one item id")
+		if len(args) != 1 {
+			return fmt.Errorf("require one item id")
+		}
+		id, err := util.ParseItemID(args[0])
+		if err != nil {
+			return err
+		}
+		// the position is forgotten only after the commit, to restore it again when the commit fails
+		restored := false
+		if err = util.AutoCommit(func(client todoist.Client, ctx context.Context) error {
+			if err := client.Item.Uncomplete(id); err != nil {
+				return err
+			}
+			restore, err := cmd.Flags().GetBool("restore-position")
+			if err != nil || !restore {
+				return err
 			}
-			id, err := todoist.NewID(args[0])
+			position, err := util.ReadPosition(id)
 			if err != nil {
 				return err
 			}
-			return client.Item.Uncomplete(id)
+			if position == nil {
+				fmt.Println("no position was saved for the item, skip restoring it")
+				return nil
+			}
+			opts := &todoist.ItemMoveOpts{}
+			switch {
+			case !position.ParentID.IsZero():
+				opts.ParentID = position.ParentID
+			case !position.SectionID.IsZero():
+				opts.SectionID = position.SectionID
+			default:
+				opts.ProjectID = position.ProjectID
+			}
+			if err = client.Item.Move(id, opts); err != nil {
+				return err
+			}
+			item := todoist.Item{ChildOrder: position.ChildOrder}
+			item.ID = id
+			restored = true
+			return client.Item.Reorder([]todoist.Item{item})
 		}); err != nil {
 			return err
 		}
+		if restored {
+			if err = util.ForgetPosition(id); err != nil {
+				return err
+			}
+		}
 		fmt.Println("Successful uncompletion of item(s).")
 		return nil
 	},
 }
 
+var itemGeotagCmd = &cobra.Command{
+	Use:   "geotag [id]",
+	Short: "attach a location to the item for nearby",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("require one item id")
+		}
+		id, err := util.ParseItemID(args[0])
+		if err != nil {
+			return err
+		}
+		remove, err := cmd.Flags().GetBool("remove")
+		if err != nil {
+			return err
+		}
+		lat, err := cmd.Flags().GetFloat64("lat")
+		if err != nil {
+			return err
+		}
+		lon, err := cmd.Flags().GetFloat64("lon")
+		if err != nil {
+			return err
+		}
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return err
+		}
+		if !remove && (!cmd.Flags().Changed("lat") || !cmd.Flags().Changed("lon")) {
+			return errors.New("require --lat and --lon, or --remove")
+		}
+		location, err := util.ParseLocation(util.Location{Lat: lat, Lon: lon, Name: name}.String())
+		if err != nil {
+			return err
+		}
+		if err = util.AutoCommit(func(client todoist.Client, ctx context.Context) error {
+			if client.Item.Resolve(id) == nil {
+				return fmt.Errorf("no such item id: %s", id)
+			}
+			// a location replaces the former one
+			if _, note := util.FindLocation(client.Note.GetAllForItem(id)); note != nil {
+				if err := client.Note.Delete(note.ID); err != nil {
+					return err
+				}
+			}
+			if remove {
+				return nil
+			}
+			note, err := todoist.NewNote(id, location.String(), &todoist.NewNoteOpts{})
+			if err != nil {
+				return err
+			}
+			_, err = client.Note.Add(*note)
+			return err
+		}); err != nil {
+			return err
+		}
+		if remove {
+			fmt.Println("succeeded to remove the location")
+		} else {
+			fmt.Printf("succeeded to attach %s\n", location)
+		}
+		return nil
+	},
+}
+
+var itemShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "show details of the item with the referenced items",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("require one item id")
+		}
+		id, err := util.ParseItemID(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		item := client.Item.Resolve(id)
+		if item == nil {
+			return fmt.Errorf("no such item id: %s", id)
+		}
+		util.AuditChange("viewed", id)
+		relations := client.Relation.Items([]todoist.Item{*item})
+		project, section := "", ""
+		if p, ok := relations.Projects[item.ProjectID]; ok {
+			project = p.ColorString()
+		}
+		if s, ok := relations.Sections[item.SectionID]; ok {
+			section = s.ColorString()
+		}
+		var labels todoist.Labels
+		for _, l := range item.Labels {
+			if v, ok := relations.Labels[l]; ok {
+				labels = append(labels, v)
+			}
+		}
+		fmt.Println(util.TableString([][]todoist.ColorStringer{
+			{todoist.NewNoColorString("content"), todoist.NewNoColorString(util.ExpandReferences(client, item.Content))},
+			{todoist.NewNoColorString("id"), todoist.NewNoColorString(item.ID.String())},
+			{todoist.NewNoColorString("project"), todoist.NewNoColorString(project)},
+			{todoist.NewNoColorString("section"), todoist.NewNoColorString(section)},
+			{todoist.NewNoColorString("labels"), labels},
+			{todoist.NewNoColorString("due"), item.Due.Date},
+			{todoist.NewNoColorString("priority"), todoist.NewNoColorString(strconv.Itoa(item.Priority))},
+		}))
+
+		if notes := client.Note.GetAllForItem(id); len(notes) > 0 {
+			for i := range notes {
+				notes[i].Content = util.ExpandReferences(client, notes[i].Content)
+			}
+			fmt.Println("\nnotes:")
+			fmt.Println(util.NoteTableString(notes))
+		}
+
+		var refs []todoist.Item
+		for _, ref := range client.Item.References(id) {
+			if i := client.Item.Resolve(ref); i != nil {
+				refs = append(refs, *i)
+			}
+		}
+		due := func(i todoist.Item) todoist.Time { return i.Due.Date }
+		if len(refs) > 0 {
+			fmt.Println("\nreferences:")
+			fmt.Println(util.ItemTableString(refs, client.Relation.Items(refs), due))
+		}
+		if backlinks := client.Item.Backlinks(id); len(backlinks) > 0 {
+			fmt.Println("\nbacklinks:")
+			fmt.Println(util.ItemTableString(backlinks, client.Relation.Items(backlinks), due))
+		}
+		return nil
+	},
+}
+
+var itemBacklinksCmd = &cobra.Command{
+	Use:   "backlinks [id]",
+	Short: "list items which reference the item",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("require one item id")
+		}
+		id, err := util.ParseItemID(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if client.Item.Resolve(id) == nil {
+			return fmt.Errorf("no such item id: %s", id)
+		}
+		items := client.Item.Backlinks(id)
+		return util.Print(util.ItemsOutput(items, client.Relation.Items(items), func(i todoist.Item) todoist.Time { return i.Due.Date }))
+	},
+}
+
+var itemNoteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "subcommand for item note",
+}
+
+var itemNoteAddCmd = &cobra.Command{
+	Use:   "add [id] [content]",
+	Short: "add a note to the item, optionally with a file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("require an item id")
+		}
+		id, err := util.ParseItemID(args[0])
+		if err != nil {
+			return err
+		}
+		if err = util.AutoCommit(func(client todoist.Client, ctx context.Context) error {
+			if client.Item.Resolve(id) == nil {
+				return fmt.Errorf("no such item id: %s", id)
+			}
+			attachment, err := noteAttachment(ctx, cmd, &client)
+			if err != nil {
+				return err
+			}
+			note, err := todoist.NewNote(id, noteContent(args[1:], attachment), &todoist.NewNoteOpts{FileAttachment: attachment})
+			if err != nil {
+				return err
+			}
+			_, err = client.Note.Add(*note)
+			return err
+		}); err != nil {
+			return err
+		}
+		fmt.Println("succeeded to add a note to the item")
+		util.AuditChange("commented", id)
+		return nil
+	},
+}
+
+// noteAttachment uploads the local or remote file of the attach flags, or links the remote one.
+// It returns an empty attachment without the flags.
+func noteAttachment(ctx context.Context, cmd *cobra.Command, client *todoist.Client) (todoist.FileAttachment, error) {
+	name, err := cmd.Flags().GetString("attach")
+	if err != nil {
+		return todoist.FileAttachment{}, err
+	}
+	rawurl, err := cmd.Flags().GetString("attach-url")
+	if err != nil {
+		return todoist.FileAttachment{}, err
+	}
+	link, err := cmd.Flags().GetBool("link")
+	if err != nil {
+		return todoist.FileAttachment{}, err
+	}
+	maxSize, err := cmd.Flags().GetString("max-size")
+	if err != nil {
+		return todoist.FileAttachment{}, err
+	}
+	if len(name) != 0 && len(rawurl) != 0 {
+		return todoist.FileAttachment{}, errors.New("require either --attach or --attach-url")
+	}
+	if link && len(rawurl) == 0 {
+		return todoist.FileAttachment{}, errors.New("--link requires --attach-url")
+	}
+	limit, err := util.ParseSize(maxSize)
+	if err != nil {
+		return todoist.FileAttachment{}, err
+	}
+	var f *util.File
+	switch {
+	case len(name) != 0:
+		f, err = util.ReadFile(name, limit)
+	case link:
+		a, err := util.LinkFile(ctx, rawurl)
+		if err != nil {
+			return todoist.FileAttachment{}, err
+		}
+		return *a, nil
+	case len(rawurl) != 0:
+		f, err = util.DownloadFile(ctx, rawurl, limit)
+	default:
+		return todoist.FileAttachment{}, nil
+	}
+	if err != nil {
+		return todoist.FileAttachment{}, err
+	}
+	a, err := f.Upload(ctx, client)
+	if err != nil {
+		return todoist.FileAttachment{}, err
+	}
+	return *a, nil
+}
+
+// noteContent is the content of the args, or the name of the attached file without them.
+func noteContent(args []string, attachment todoist.FileAttachment) string {
+	if len(args) == 0 {
+		return attachment.FileName
+	}
+	return strings.Join(args, " ")
+}
+
+func addAttachFlags(cmd *cobra.Command) {
+	cmd.Flags().String("attach", "", "file to upload and attach")
+	cmd.Flags().String("attach-url", "", "url of a remote file to download and attach")
+	cmd.Flags().Bool("link", false, "attach the url of --attach-url as it is, without downloading it")
+	cmd.Flags().String("max-size", "25MB", "largest file to upload")
+}
+
 func init() {
 	RootCmd.AddCommand(itemCmd)
 	itemCmd.AddCommand(itemListCmd)
-	itemAddCmd.Flags().StringP("project", "p", "inbox", "project id or name")
+	itemListCmd.Flags().Bool("select", false, selectFlagUsage)
+	itemListCmd.Flags().Bool("has-attachment", false, "list items with a note attachment")
+	itemListCmd.Flags().String("type", "", "type of the attachment (image, pdf, video, audio, document or a mime type), implies --has-attachment")
+	itemAddCmd.Flags().StringP("project", "p", "inbox", "project id or name (default of the config overrides inbox)")
 	itemAddCmd.Flag("project").Annotations = map[string][]string{cobra.BashCompCustom: {"__todoist_project_id"}}
 	itemAddCmd.Flags().StringP("label", "l", "", "label id or name(s) (delimiter: ,)")
 	itemAddCmd.Flag("label").Annotations = map[string][]string{cobra.BashCompCustom: {"__todoist_label_id"}}
 	itemAddCmd.Flags().StringP("due", "d", "", "due date")
+	itemAddCmd.Flags().Bool("pick-date", false, "pick the due date on a calendar")
 	itemAddCmd.Flags().Int("priority", 1, "priority")
 	itemCmd.AddCommand(itemAddCmd)
 	itemUpdateCmd.Flags().StringP("label", "l", "", "label id(s) or name(s) (delimiter: ,)")
 	itemUpdateCmd.Flag("label").Annotations = map[string][]string{cobra.BashCompCustom: {"__todoist_label_id"}}
 	itemUpdateCmd.Flags().StringP("due", "d", "", "due date")
+	itemUpdateCmd.Flags().Bool("pick-date", false, "pick the due date on a calendar")
 	itemUpdateCmd.Flags().Int("priority", 1, "priority")
+	itemUpdateCmd.Flags().StringSlice("fields", nil, "fields to show in the diff (default: all)")
 	itemCmd.AddCommand(itemUpdateCmd)
 	itemCmd.AddCommand(itemDeleteCmd)
 	itemMoveCmd.Flags().StringP("parent", "i", "", "parent item id")
 	itemMoveCmd.Flag("parent").Annotations = map[string][]string{cobra.BashCompCustom: {"__todoist_item_id"}}
-	itemMoveCmd.Flags().StringP("project", "p", "", "project id")
+	itemMoveCmd.Flags().StringP("project", "p", "", "project id or name")
 	itemMoveCmd.Flag("project").Annotations = map[string][]string{cobra.BashCompCustom: {"__todoist_project_id"}}
+	itemMoveCmd.Flags().StringP("section", "s", "", "section id or name")
+	itemMoveCmd.Flags().StringP("filter", "f", "", "move all the items matching the filter query")
+	itemMoveCmd.Flags().StringSlice("fields", nil, "fields to show in the diff (default: all)")
 	itemCmd.AddCommand(itemMoveCmd)
+	itemCompleteCmd.Flags().String("from-quickfix", "", "complete the items of a quickfix list written by item list -o vimgrep or :w of the quickfix window (- for stdin, which requires --yes)")
+	itemCompleteCmd.Flags().Bool("yes", false, "complete the items of the quickfix list without confirmation")
 	itemCmd.AddCommand(itemCompleteCmd)
+	itemUncompleteCmd.Flags().Bool("restore-position", false, "restore project, section, parent and order saved at completion")
 	itemCmd.AddCommand(itemUncompleteCmd)
+	itemGeotagCmd.Flags().Float64("lat", 0, "latitude")
+	itemGeotagCmd.Flags().Float64("lon", 0, "longitude")
+	itemGeotagCmd.Flags().String("name", "", "name of the place")
+	itemGeotagCmd.Flags().Bool("remove", false, "remove the location")
+	itemCmd.AddCommand(itemGeotagCmd)
+	itemCmd.AddCommand(itemShowCmd)
+	itemCmd.AddCommand(itemBacklinksCmd)
+	addAttachFlags(itemNoteAddCmd)
+	itemNoteCmd.AddCommand(itemNoteAddCmd)
+	itemCmd.AddCommand(itemNoteCmd)
 }
@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"github.com/kobtea/go-todoist/cmd/util"
 	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
-	"os"
 	"strings"
 )
 
@@ -27,8 +25,7 @@ var labelListCmd = &cobra.Command{
 			return err
 		}
 		labels := client.Label.GetAll()
-		fmt.Println(util.LabelTableString(labels))
-		return nil
+		return util.Print(util.LabelsOutput(labels))
 	},
 }
 
@@ -173,10 +170,7 @@ var labelDeleteCmd = &cobra.Command{
 					return fmt.Errorf("invalid label id: %s", id)
 				}
 				fmt.Println(util.LabelTableString([]todoist.Label{*label}))
-				reader := bufio.NewReader(os.Stdin)
-				fmt.Print("are you sure to delete above label? (y/[n]): ")
-				ans, err := reader.ReadString('\n')
-				if ans != "y\n" || err != nil {
+				if !util.Confirm("are you sure to delete above label?") {
 					fmt.Println("abort")
 					return errors.New("abort")
 				}
@@ -193,6 +187,84 @@ var labelDeleteCmd = &cobra.Command{
 	},
 }
 
+var labelApplyCmd = &cobra.Command{
+	Use:   "apply [name]",
+	Short: "add or remove the label to all the items matching the filter",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("require a label name to apply")
+		}
+		filter, err := cmd.Flags().GetString("filter")
+		if err != nil {
+			return err
+		}
+		remove, err := cmd.Flags().GetBool("remove")
+		if err != nil {
+			return err
+		}
+		if len(filter) == 0 {
+			return errors.New("require --filter")
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		label := client.Label.FindOneByName(args[0])
+		if label == nil {
+			return fmt.Errorf("no such label: %s", args[0])
+		}
+		matched, err := client.Item.FindByQuery(filter)
+		if err != nil {
+			return err
+		}
+		var items []todoist.Item
+		for _, i := range matched {
+			if i.IsChecked() || util.HasLabel(i, label.ID) != remove {
+				continue
+			}
+			if remove {
+				var labels []todoist.ID
+				for _, id := range i.Labels {
+					if id != label.ID {
+						labels = append(labels, id)
+					}
+				}
+				i.Labels = labels
+			} else {
+				i.Labels = append(i.Labels, label.ID)
+			}
+			items = append(items, i)
+		}
+		if len(items) == 0 {
+			fmt.Println("no items to change")
+			return nil
+		}
+		relations := client.Relation.Items(items)
+		verb := "add " + label.String() + " to"
+		if remove {
+			verb = "remove " + label.String() + " from"
+		}
+		if !util.ConfirmBulk(verb, items, relations) {
+			fmt.Println("abort")
+			return nil
+		}
+		for _, i := range items {
+			if err = client.Item.UpdateLabels(i.ID, i.Labels); err != nil {
+				return err
+			}
+		}
+		ctx := context.Background()
+		if err = client.Commit(ctx); err != nil {
+			return err
+		}
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+			return err
+		}
+		fmt.Printf("succeeded to update %d item(s)\n", len(items))
+		return nil
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(labelCmd)
 	labelCmd.AddCommand(labelListCmd)
@@ -207,4 +279,7 @@ func init() {
 	labelUpdateCmd.Flags().Bool("un-favorite", false, "is not favorite")
 	labelCmd.AddCommand(labelUpdateCmd)
 	labelCmd.AddCommand(labelDeleteCmd)
+	labelApplyCmd.Flags().StringP("filter", "f", "", "filter query of the items to label")
+	labelApplyCmd.Flags().Bool("remove", false, "remove the label instead of adding it")
+	labelCmd.AddCommand(labelApplyCmd)
 }
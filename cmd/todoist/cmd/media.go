@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+)
+
+// mediaCmd represents the media command
+var mediaCmd = &cobra.Command{
+	Use:   "media",
+	Short: "subcommand for attachments of notes",
+}
+
+var mediaListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list attachments of the notes of items and projects",
+	Long: `List the attachments of the notes of items and projects, from the newest,
+with their item, type, size and url. --project lists those of the project
+and its sub projects.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectStr, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return err
+		}
+		kind, err := cmd.Flags().GetString("type")
+		if err != nil {
+			return err
+		}
+		if len(kind) != 0 {
+			if err = util.CheckAttachmentType(kind); err != nil {
+				return err
+			}
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		projects := map[todoist.ID]bool{}
+		if len(projectStr) != 0 {
+			projectID, err := util.ResolveProjectID(client, projectStr)
+			if err != nil {
+				return err
+			}
+			projects[projectID] = true
+			for added := true; added; {
+				added = false
+				for _, p := range client.Project.GetAll() {
+					if !projects[p.ID] && projects[p.ParentID] {
+						projects[p.ID] = true
+						added = true
+					}
+				}
+			}
+		}
+		names := map[todoist.ID]todoist.Project{}
+		for _, p := range client.Project.GetAll() {
+			names[p.ID] = p
+		}
+		return util.Print(util.MediaOutput(util.ListMedia(client, projects, kind), names))
+	},
+}
+
+func init() {
+	mediaListCmd.Flags().StringP("project", "p", "", "project id or name")
+	mediaListCmd.Flags().String("type", "", "type of the attachment (image, pdf, video, audio, document or a mime type)")
+	mediaCmd.AddCommand(mediaListCmd)
+	RootCmd.AddCommand(mediaCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+)
+
+// nearbyCmd represents the nearby command
+var nearbyCmd = &cobra.Command{
+	Use:   "nearby",
+	Short: "show items located close to a point",
+	Long: `Show items located close to a point, the nearest first.
+
+Items are located by item geotag, which keeps the location in a note of the item.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("lat") || !cmd.Flags().Changed("lon") {
+			return errors.New("require --lat and --lon")
+		}
+		lat, err := cmd.Flags().GetFloat64("lat")
+		if err != nil {
+			return err
+		}
+		lon, err := cmd.Flags().GetFloat64("lon")
+		if err != nil {
+			return err
+		}
+		radiusStr, err := cmd.Flags().GetString("radius")
+		if err != nil {
+			return err
+		}
+		radius, err := util.ParseDistance(radiusStr)
+		if err != nil {
+			return err
+		}
+		labelName, err := cmd.Flags().GetString("label")
+		if err != nil {
+			return err
+		}
+		point, err := util.ParseLocation(util.Location{Lat: lat, Lon: lon}.String())
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		var label *todoist.Label
+		if len(labelName) != 0 {
+			if label = client.Label.FindOneByName(labelName); label == nil {
+				return errors.New("no such label: " + labelName)
+			}
+		}
+		var items []util.NearbyItem
+		var plain []todoist.Item
+		for _, i := range util.NearbyItems(client, *point, radius) {
+			if label == nil || util.HasLabel(i.Item, label.ID) {
+				items = append(items, i)
+				plain = append(plain, i.Item)
+			}
+		}
+		return util.Print(util.NearbyOutput(items, client.Relation.Items(plain)))
+	},
+}
+
+func init() {
+	nearbyCmd.Flags().Float64("lat", 0, "latitude of the point")
+	nearbyCmd.Flags().Float64("lon", 0, "longitude of the point")
+	nearbyCmd.Flags().StringP("radius", "r", "2km", "radius, e.g. 500m, 2km or 1mi")
+	nearbyCmd.Flags().StringP("label", "l", "", "show only the items with the label, e.g. errand")
+	RootCmd.AddCommand(nearbyCmd)
+}
@@ -1,14 +1,14 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"github.com/kobtea/go-todoist/cmd/util"
 	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
-	"os"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -27,8 +27,7 @@ var projectListCmd = &cobra.Command{
 			return err
 		}
 		projects := client.Project.GetAll()
-		fmt.Println(util.ProjectTableString(projects))
-		return nil
+		return util.Print(util.ProjectsOutput(projects))
 	},
 }
 
@@ -119,6 +118,7 @@ var projectUpdateCmd = &cobra.Command{
 		if project == nil {
 			return fmt.Errorf("no such project id: %s", id)
 		}
+		oldName := project.Name
 		if name, err := cmd.Flags().GetString("name"); err != nil {
 			return err
 		} else {
@@ -200,6 +200,9 @@ var projectUpdateCmd = &cobra.Command{
 		}
 		fmt.Println("succeeded to update the project")
 		fmt.Println(util.ProjectTableString([]todoist.Project{*syncedProject}))
+		if syncedProject.Name != oldName {
+			return renameProjectReferences(oldName, syncedProject.Name)
+		}
 		return nil
 	},
 }
@@ -218,10 +221,7 @@ var projectDeleteCmd = &cobra.Command{
 					return fmt.Errorf("invalid project id: %s", id)
 				}
 				fmt.Println(util.ProjectTableString([]todoist.Project{*project}))
-				reader := bufio.NewReader(os.Stdin)
-				fmt.Print("are you sure to delete above project? (y/[n]): ")
-				ans, err := reader.ReadString('\n')
-				if ans != "y\n" || err != nil {
+				if !util.Confirm("are you sure to delete above project?") {
 					fmt.Println("abort")
 					return errors.New("abort")
 				}
@@ -276,6 +276,213 @@ var projectUnarchiveCmd = &cobra.Command{
 	},
 }
 
+var projectShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "show details of the project",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return errors.New("require project id to show")
+		}
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			return err
+		}
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return err
+		}
+		return util.ProcessID(args[0], func(id todoist.ID) error {
+			project := client.Project.Resolve(id)
+			if project == nil {
+				return fmt.Errorf("no such project id: %s", id)
+			}
+			parent := ""
+			if p := client.Project.Resolve(project.ParentID); p != nil {
+				parent = p.ColorString()
+			}
+			var collaborators []string
+			for _, c := range client.Collaborator.FindByProjectID(id) {
+				collaborators = append(collaborators, c.String())
+			}
+			fmt.Println(util.TableString([][]todoist.ColorStringer{
+				{todoist.NewNoColorString("name"), project},
+				{todoist.NewNoColorString("id"), todoist.NewNoColorString(project.ID.String())},
+				{todoist.NewNoColorString("color"), todoist.NewNoColorString(strconv.Itoa(project.Color))},
+				{todoist.NewNoColorString("parent"), todoist.NewNoColorString(parent)},
+				{todoist.NewNoColorString("shared"), todoist.NewNoColorString(strconv.FormatBool(project.Shared))},
+				{todoist.NewNoColorString("collaborators"), todoist.NewNoColorString(strings.Join(collaborators, ", "))},
+				{todoist.NewNoColorString("view_style"), todoist.NewNoColorString(project.ViewStyle)},
+			}))
+
+			if notes := client.ProjectNote.GetAllForProject(id); len(notes) > 0 {
+				fmt.Println("\nnotes:")
+				fmt.Println(util.NoteTableString(notes))
+			}
+
+			var items []todoist.Item
+			for _, i := range client.Item.FindByProjectIDs([]todoist.ID{id}) {
+				if !i.IsChecked() {
+					items = append(items, i)
+				}
+			}
+			if sections := client.Section.FindByProjectID(id); len(sections) > 0 {
+				counts := map[todoist.ID]int{}
+				for _, i := range items {
+					counts[i.SectionID]++
+				}
+				fmt.Println("\nsections:")
+				fmt.Println(util.SectionTableString(sections, counts))
+			}
+
+			var upcoming []todoist.Item
+			for _, i := range items {
+				if !i.Due.Date.IsZero() {
+					upcoming = append(upcoming, i)
+				}
+			}
+			sort.Slice(upcoming, func(i, j int) bool {
+				return upcoming[i].Due.Date.Local().Before(upcoming[j].Due.Date.Local())
+			})
+			if len(upcoming) > limit {
+				upcoming = upcoming[:limit]
+			}
+			if len(upcoming) > 0 {
+				relations := client.Relation.Items(upcoming)
+				fmt.Printf("\nupcoming (%d/%d items):\n", len(upcoming), len(items))
+				fmt.Println(util.ItemTableString(upcoming, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+			}
+
+			if all {
+				// completed items are not in the sync data, so print them page by page
+				fmt.Println("\ncompleted:")
+				p := client.Archive.Pager(&todoist.ArchiveItemsOpts{ProjectID: id, Limit: limit})
+				for p.Next(context.Background()) {
+					completed := p.Items()
+					relations := client.Relation.Items(completed)
+					fmt.Println(util.ItemTableString(completed, relations, func(i todoist.Item) todoist.Time { return i.CompletedDate }))
+				}
+				if err := p.Err(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+var projectNoteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "subcommand for project note",
+}
+
+var projectNoteListCmd = &cobra.Command{
+	Use:   "list [project id]",
+	Short: "list notes of the project",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return errors.New("require project id")
+		}
+		return util.ProcessID(args[0], func(id todoist.ID) error {
+			if project := client.Project.Resolve(id); project == nil {
+				return fmt.Errorf("no such project id: %s", id)
+			}
+			return util.Print(util.NotesOutput(client.ProjectNote.GetAllForProject(id)))
+		})
+	},
+}
+
+var projectNoteAddCmd = &cobra.Command{
+	Use:   "add [project id] [content]",
+	Short: "add a note to the project",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := util.AutoCommit(func(client todoist.Client, ctx context.Context) error {
+			if len(args) < 1 {
+				return errors.New("require project id")
+			}
+			return util.ProcessID(args[0], func(id todoist.ID) error {
+				if project := client.Project.Resolve(id); project == nil {
+					return fmt.Errorf("no such project id: %s", id)
+				}
+				attachment, err := noteAttachment(ctx, cmd, &client)
+				if err != nil {
+					return err
+				}
+				note, err := todoist.NewProjectNote(id, noteContent(args[1:], attachment), &todoist.NewNoteOpts{FileAttachment: attachment})
+				if err != nil {
+					return err
+				}
+				_, err = client.ProjectNote.Add(*note)
+				return err
+			})
+		}); err != nil {
+			return err
+		}
+		fmt.Println("succeeded to add a note to the project")
+		return nil
+	},
+}
+
+var projectNoteDeleteCmd = &cobra.Command{
+	Use:   "delete [note id]",
+	Short: "delete a note of the project",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := util.AutoCommit(func(client todoist.Client, ctx context.Context) error {
+			if len(args) == 0 {
+				return errors.New("require note id to delete")
+			}
+			return util.ProcessID(args[0], func(id todoist.ID) error {
+				note := client.ProjectNote.Resolve(id)
+				if note == nil {
+					return fmt.Errorf("invalid note id: %s", id)
+				}
+				fmt.Println(util.NoteTableString([]todoist.Note{*note}))
+				if !util.Confirm("are you sure to delete above note?") {
+					fmt.Println("abort")
+					return errors.New("abort")
+				}
+				return client.ProjectNote.Delete(id)
+			})
+		}); err != nil {
+			if err.Error() == "abort" {
+				return nil
+			}
+			return err
+		}
+		fmt.Println("succeeded to delete the note")
+		return nil
+	},
+}
+
+// renameProjectReferences offers to rewrite the references to the old name of a renamed project
+// in the config and the focus, which would break silently otherwise.
+func renameProjectReferences(oldName, newName string) error {
+	rename, err := util.NewProjectRename(oldName, newName)
+	if err != nil {
+		return err
+	}
+	if len(rename.Refs) == 0 {
+		return nil
+	}
+	fmt.Println(util.ProjectReferencesString(rename.Refs))
+	if !util.Confirm(fmt.Sprintf("rewrite %d reference(s) to %s?", len(rename.Refs), oldName)) {
+		fmt.Printf("keep the references to %s\n", oldName)
+		return nil
+	}
+	if err = rename.Apply(); err != nil {
+		return err
+	}
+	fmt.Printf("rewrote %d reference(s) to %s\n", len(rename.Refs), newName)
+	return nil
+}
+
 func init() {
 	RootCmd.AddCommand(projectCmd)
 	projectCmd.AddCommand(projectListCmd)
@@ -298,4 +505,12 @@ func init() {
 	projectCmd.AddCommand(projectDeleteCmd)
 	projectCmd.AddCommand(projectArchiveCmd)
 	projectCmd.AddCommand(projectUnarchiveCmd)
+	projectShowCmd.Flags().IntP("limit", "n", 10, "number of upcoming items to show, and completed items per page")
+	projectShowCmd.Flags().BoolP("all", "a", false, "show completed items too")
+	projectCmd.AddCommand(projectShowCmd)
+	projectNoteCmd.AddCommand(projectNoteListCmd)
+	addAttachFlags(projectNoteAddCmd)
+	projectNoteCmd.AddCommand(projectNoteAddCmd)
+	projectNoteCmd.AddCommand(projectNoteDeleteCmd)
+	projectCmd.AddCommand(projectNoteCmd)
 }
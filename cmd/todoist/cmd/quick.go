@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"sort"
+	"strings"
+	"time"
+)
+
+// quickCmd represents the quick command
+var quickCmd = &cobra.Command{
+	Use:   "quick [text]",
+	Short: "add an item with the quick add syntax",
+	Long: `Add an item written like "Write the report #Work /Triage @deep p1".
+
+Without a project in the text, the routes of the config choose one by the time of day:
+
+  "routes": [
+    {"days": "mon-fri", "hours": "9-18", "project": "Work"},
+    {"project": "Personal"}
+  ]
+
+The default project of the config is taken when no route matches.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noRouting, err := cmd.Flags().GetBool("no-routing")
+		if err != nil {
+			return err
+		}
+		due, err := cmd.Flags().GetString("due")
+		if err != nil {
+			return err
+		}
+		config, err := util.LoadConfig()
+		if err != nil {
+			config = &util.Config{}
+		}
+		if noRouting {
+			config.Routes = nil
+		}
+		q, err := util.ParseQuickAdd(strings.Join(args, " "), config.Routes, time.Now())
+		if err != nil {
+			return err
+		}
+		if len(q.Project) == 0 {
+			q.Project = config.DefaultProject
+		}
+
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		item := todoist.Item{Content: q.Content, Priority: q.Priority}
+		if len(due) > 0 {
+			if err = client.Item.SetDue(&item, due); err != nil {
+				return err
+			}
+		}
+		if len(q.Project) != 0 {
+			if item.ProjectID, err = util.ResolveProjectID(client, q.Project); err != nil {
+				return err
+			}
+		}
+		if len(q.Section) != 0 {
+			if item.ProjectID.IsZero() {
+				if user := client.User.Get(); user != nil {
+					item.ProjectID = user.InboxProjectID
+				}
+			}
+			if item.SectionID, err = util.ResolveSectionID(client, item.ProjectID, q.Section); err != nil {
+				return err
+			}
+		}
+		for _, name := range q.Labels {
+			label := client.Label.FindOneByName(name)
+			if label == nil {
+				return fmt.Errorf("no such label: %s", name)
+			}
+			item.Labels = append(item.Labels, label.ID)
+		}
+
+		if _, err = client.Item.Add(item); err != nil {
+			return err
+		}
+		ctx := context.Background()
+		if err = client.Commit(ctx); err != nil {
+			return err
+		}
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+			return err
+		}
+		items := client.Item.FindByContent(q.Content)
+		if len(items) == 0 {
+			return errors.New("failed to add this item. it may be failed to sync")
+		}
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].DateAdded.Before(items[j].DateAdded)
+		})
+		syncedItem := items[len(items)-1]
+		if q.Routed {
+			fmt.Printf("routed to #%s\n", q.Project)
+		}
+		relations := client.Relation.Items([]todoist.Item{syncedItem})
+		fmt.Println("succeeded to add an item")
+		fmt.Println(util.ItemTableString([]todoist.Item{syncedItem}, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+		return nil
+	},
+}
+
+func init() {
+	quickCmd.Flags().StringP("due", "d", "", "due date")
+	quickCmd.Flags().Bool("no-routing", false, "ignore the routes of the config")
+	RootCmd.AddCommand(quickCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// recentCmd represents the recent command
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "list items recently viewed, edited or commented",
+	Long: `List the items recently viewed, edited or commented, the latest first.
+
+The touches come from the local audit log of this CLI, and the latest activity
+events of your account unless --local is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			return err
+		}
+		local, err := cmd.Flags().GetBool("local")
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		touches, err := util.ReadAudit()
+		if err != nil {
+			return err
+		}
+		if !local {
+			// the activity log may be unavailable, such as on a free plan
+			res, err := client.Activity.Get(context.Background(), &todoist.ActivityGetOpts{Limit: 100})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "list the local audit log only: %s\n", err)
+			} else {
+				var userID todoist.ID
+				if user := client.User.Get(); user != nil {
+					userID = user.ID
+				}
+				touches = append(touches, util.EventTouches(res.Events, userID)...)
+			}
+		}
+		items := util.RecentItems(client, touches, limit)
+		var plain []todoist.Item
+		for _, i := range items {
+			plain = append(plain, i.Item)
+		}
+		return util.Print(util.RecentOutput(items, client.Relation.Items(plain)))
+	},
+}
+
+func init() {
+	recentCmd.Flags().IntP("limit", "n", 20, "number of items to list")
+	recentCmd.Flags().Bool("local", false, "list the local audit log only, without the activity events")
+	RootCmd.AddCommand(recentCmd)
+}
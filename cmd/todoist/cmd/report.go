@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "subcommand for reports",
+}
+
+var reportRecurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "show how recurring items were completed over their past cycles",
+	Long: `Show how recurring items were completed over their past cycles.
+
+Each cycle is on time (o) when completed until its due date, late (~) when
+completed until the next due date, or skipped (x). Completions are taken from
+the activity log, or from the completed archive when the log is not available.
+Irregular recurrences, such as every mon, fri, are not reported.`,
+	RunE: cachedView(func(cmd *cobra.Command, args []string, client *todoist.Client) error {
+		n, err := cmd.Flags().GetInt("cycles")
+		if err != nil {
+			return err
+		}
+		if n <= 0 {
+			return fmt.Errorf("invalid number of cycles: %d", n)
+		}
+		var items []todoist.Item
+		since := time.Now()
+		for _, i := range client.Item.GetAll() {
+			if i.IsChecked() || !i.Due.IsRecurring || i.Due.Date.IsZero() {
+				continue
+			}
+			r, err := todoist.ParseRecurrence(i.Due.String)
+			if err != nil {
+				continue
+			}
+			items = append(items, i)
+			if start := r.Before(i.Due.Date.EndOfDay(time.Local).Time, n+1); start.Before(since) {
+				since = start
+			}
+		}
+		if len(items) == 0 {
+			fmt.Println("no recurring items")
+			return nil
+		}
+
+		completions := map[todoist.ID][]time.Time{}
+		ctx := context.Background()
+		events, err := client.Activity.GetAllPages(ctx, &todoist.ActivityGetOpts{
+			ObjectType: "item",
+			EventType:  "completed",
+			Since:      todoist.Time{Time: since},
+		})
+		if err == nil {
+			for _, e := range events {
+				completions[e.ObjectID] = append(completions[e.ObjectID], e.EventDate.Time)
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "activity log is not available, use the completed archive: %s\n", err)
+			completed, err := client.Completed.GetAllPages(ctx, &todoist.CompletedGetAllOpts{
+				Since: todoist.Time{Time: since},
+			})
+			if err != nil {
+				return err
+			}
+			for _, c := range completed.Items {
+				completions[c.TaskID] = append(completions[c.TaskID], c.CompletedDate.Time)
+			}
+		}
+
+		var reports []util.RecurringReport
+		for _, i := range items {
+			report, err := util.NewRecurringReport(i, completions[i.ID], n)
+			if err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+		sort.SliceStable(reports, func(i, j int) bool {
+			return reports[i].Adherence() < reports[j].Adherence()
+		})
+		return util.Print(util.RecurringOutput(reports))
+	}),
+}
+
+var reportBillingCmd = &cobra.Command{
+	Use:   "billing",
+	Short: "list the completed items of a project with their tracked time as csv",
+	Long: `List the completed items of a project and its sub projects with their tracked time,
+and the totals charged at the hourly rate. The output is csv unless --output is given.
+
+The tracked time of an item is its duration, or the sum of its notes like
+"tracked 1h30m", "spent: 45m" or "time 2h" without a duration.`,
+	RunE: cachedView(func(cmd *cobra.Command, args []string, client *todoist.Client) error {
+		projectStr, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return err
+		}
+		if len(projectStr) == 0 {
+			return errors.New("require --project")
+		}
+		rate, err := cmd.Flags().GetFloat64("rate")
+		if err != nil {
+			return err
+		}
+		var since, until time.Time
+		if s, err := cmd.Flags().GetString("since"); err != nil {
+			return err
+		} else if len(s) != 0 {
+			if since, err = time.ParseInLocation("2006-01-02", s, time.Local); err != nil {
+				return fmt.Errorf("invalid date: %s", s)
+			}
+		}
+		if s, err := cmd.Flags().GetString("until"); err != nil {
+			return err
+		} else if len(s) != 0 {
+			if until, err = time.ParseInLocation("2006-01-02", s, time.Local); err != nil {
+				return fmt.Errorf("invalid date: %s", s)
+			}
+			// until the end of the day
+			until = until.AddDate(0, 0, 1)
+		}
+		if !cmd.Flags().Changed("output") {
+			util.OutputFormat = "csv"
+		}
+
+		projectID, err := util.ResolveProjectID(client, projectStr)
+		if err != nil {
+			return err
+		}
+		projects := map[todoist.ID]bool{projectID: true}
+		for added := true; added; {
+			added = false
+			for _, p := range client.Project.GetAll() {
+				if !projects[p.ID] && projects[p.ParentID] {
+					projects[p.ID] = true
+					added = true
+				}
+			}
+		}
+		completed, err := client.Completed.GetAllPages(context.Background(), &todoist.CompletedGetAllOpts{
+			Since:         todoist.Time{Time: since},
+			Until:         todoist.Time{Time: until},
+			AnnotateNotes: true,
+		})
+		if err != nil {
+			return err
+		}
+		var entries []util.BillingEntry
+		for _, c := range completed.Items {
+			if !projects[c.ProjectID] {
+				continue
+			}
+			item := c
+			// the completed archive has no duration, which the cache may have
+			if cached := client.Item.Resolve(c.TaskID); cached != nil && item.Duration == nil {
+				item.Duration = cached.Duration
+			}
+			var project string
+			if p := client.Project.Resolve(c.ProjectID); p != nil {
+				project = p.Name
+			}
+			entries = append(entries, util.BillingEntry{
+				Item:      item,
+				Project:   project,
+				Completed: c.CompletedDate.Time,
+				Tracked:   util.TrackedTime(item, c.Notes),
+			})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Completed.Before(entries[j].Completed)
+		})
+		return util.Print(util.BillingOutput(util.NewBilling(entries, rate)))
+	}),
+}
+
+var reportAgingPriorityCmd = &cobra.Command{
+	Use:   "aging-priority",
+	Short: "show long-lived items of low priority with suggested escalations",
+	Long: `Show the items added before --if-older, such as 14d, 2w or 2019-03-10, with a lower
+priority than --suggest, from the oldest. Without --suggest, each item is escalated by one level.
+--apply commits the escalations after a confirmation, and --emit-script prints them as a script
+of todoist commands to review, edit and run later instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		older, err := cmd.Flags().GetString("if-older")
+		if err != nil {
+			return err
+		}
+		since, err := util.ParseSince(older, time.Now())
+		if err != nil {
+			return err
+		}
+		suggested := 0
+		if s, err := cmd.Flags().GetString("suggest"); err != nil {
+			return err
+		} else if len(s) != 0 {
+			if suggested, err = util.ParsePriority(s); err != nil {
+				return err
+			}
+		}
+		apply, err := cmd.Flags().GetBool("apply")
+		if err != nil {
+			return err
+		}
+		emitScript, err := cmd.Flags().GetBool("emit-script")
+		if err != nil {
+			return err
+		}
+		if apply && emitScript {
+			return errors.New("--apply and --emit-script are exclusive")
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		agings := util.NewPriorityAgings(client.Item.GetAll(), since, suggested, time.Now())
+		if emitScript {
+			var commands []util.ScriptCommand
+			for _, a := range agings {
+				commands = append(commands, util.ScriptCommand{
+					Comment: fmt.Sprintf("%s -> %s: %s", util.FormatPriority(a.Item.Priority), util.FormatPriority(a.Suggested), a.Item.Content),
+					Args:    []string{"item", "update", a.Item.ID.String(), "--priority", strconv.Itoa(a.Suggested)},
+				})
+			}
+			title := cmd.CommandPath()
+			cmd.Flags().Visit(func(f *pflag.Flag) {
+				if f.Name != "emit-script" {
+					title += " --" + f.Name + " " + util.ShellQuote(f.Value.String())
+				}
+			})
+			fmt.Print(util.ScriptString("planned by "+title, commands))
+			return nil
+		}
+		if len(agings) == 0 && util.OutputFormat == "table" {
+			fmt.Printf("no items of low priority added before %s\n", since.Format("2006-01-02"))
+			return nil
+		}
+		var items []todoist.Item
+		for _, a := range agings {
+			items = append(items, a.Item)
+		}
+		relations := client.Relation.Items(items)
+		if !apply {
+			return util.Print(util.PriorityAgingOutput(agings, relations))
+		}
+		if !util.ConfirmBulk("escalate", items, relations) {
+			fmt.Println("abort")
+			return nil
+		}
+		var ops []todoist.Operation
+		for _, a := range agings {
+			item := a.Item
+			item.Priority = a.Suggested
+			ops = append(ops, func(c *todoist.Client) error {
+				_, err := c.Item.Update(item)
+				return err
+			})
+		}
+		ctx := context.Background()
+		results, err := client.BatchExecute(ctx, ops, func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\rescalated %d/%d", done, total)
+		})
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for i, r := range results {
+			if r.Err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "failed to escalate %s: %s\n", agings[i].Item.ID, r.Err)
+			}
+		}
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to escalate %d of %d item(s)", failed, len(agings))
+		}
+		fmt.Printf("escalated %d item(s)\n", len(agings))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(reportCmd)
+	reportRecurringCmd.Flags().IntP("cycles", "n", 8, "number of past cycles")
+	reportCmd.AddCommand(reportRecurringCmd)
+	reportBillingCmd.Flags().StringP("project", "p", "", "project id or name")
+	reportBillingCmd.Flags().String("since", "", "first day of completions (YYYY-MM-DD)")
+	reportBillingCmd.Flags().String("until", "", "last day of completions (YYYY-MM-DD)")
+	reportBillingCmd.Flags().Float64("rate", 0, "hourly rate")
+	reportCmd.AddCommand(reportBillingCmd)
+	reportAgingPriorityCmd.Flags().String("if-older", "14d", "report the items added before the period, such as 14d or 2w, or the date")
+	reportAgingPriorityCmd.Flags().String("suggest", "", "priority to escalate to (p1, p2, p3), one level up by default")
+	reportAgingPriorityCmd.Flags().Bool("apply", false, "commit the escalations after a confirmation")
+	reportAgingPriorityCmd.Flags().Bool("emit-script", false, "print the escalations as a script of todoist commands instead")
+	reportCmd.AddCommand(reportAgingPriorityCmd)
+}
@@ -43,8 +43,7 @@ var reviewCmd = &cobra.Command{
 			}
 		})
 		relations := client.Relation.Items(completed.Items)
-		fmt.Println(util.ItemTableString(completed.Items, relations, func(i todoist.Item) todoist.Time { return i.CompletedDate }))
-		return nil
+		return util.Print(util.ItemsOutput(completed.Items, relations, func(i todoist.Item) todoist.Time { return i.CompletedDate }))
 	},
 }
 
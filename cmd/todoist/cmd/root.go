@@ -3,28 +3,72 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 var cfgFile string
+var noHooks bool
 
 var RootCmd = &cobra.Command{
-	Use:   "todoist",
-	Short: "Command line tool for todoist.",
+	Use:                    "todoist",
+	Short:                  "Command line tool for todoist.",
 	BashCompletionFunction: bashCompletionFunc,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// the first command which builds a client starts the setup without a config
+		config, err := util.LoadConfig()
+		// without a config, there are no preferences
+		if err == nil {
+			if err = config.ApplyPreferences(cmd.Flags().Changed("output")); err != nil {
+				return err
+			}
+		}
+		// the client which the command builds offers them, not to build another one here
+		util.OfferPendingOnce = offersPending(cmd)
+		return nil
+	},
+	// cobra runs this only when the command succeeded
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if noHooks {
+			return
+		}
+		path := strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+		if err := util.RunHook(path, args); err != nil {
+			fmt.Fprintf(os.Stderr, "hook of %s failed: %s\n", path, err)
+		}
+	},
 }
 
 // Execute adds all child commands to the root command sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	err := RootCmd.Execute()
+	util.StartupProfile.Mark("run command")
+	util.StartupProfile.Print(os.Stderr)
+	if e, ok := err.(exitError); ok {
+		os.Exit(e.code)
+	}
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
+// exitError makes the process exit with the code without a message, for commands
+// whose exit code is a part of the output, such as status for i3blocks.
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
@@ -32,10 +76,17 @@ func init() {
 	// Cobra supports Persistent Flags, which, if defined here,
 	// will be global for your application.
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.todoist.yaml)")
+	RootCmd.PersistentFlags().BoolVar(&noHooks, "no-hooks", false, "do not run hooks of the config")
+	RootCmd.PersistentFlags().BoolVar(&util.StartupProfile.Enabled, "profile-startup", false, "print the time taken by each step of the startup to stderr")
+	RootCmd.PersistentFlags().BoolVar(&util.NoFocus, "no-focus", false, "ignore the current focus of lists")
+	RootCmd.PersistentFlags().BoolVar(&util.NoViewCache, "no-view-cache", false, "render views, such as today, without the cached output")
+	RootCmd.PersistentFlags().BoolVar(&util.Metered.Enabled, "metered", false, "sync incrementally and less often, for metered connections")
+	RootCmd.PersistentFlags().StringVarP(&util.OutputFormat, "output", "o", "table", "output format of lists (table, csv, json, vimgrep, scriptfilter, template=TEMPLATE)")
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	util.StartupProfile.Mark("init")
 	if cfgFile != "" { // enable ability to specify config file via flag
 		viper.SetConfigFile(cfgFile)
 	}
@@ -46,6 +97,29 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	}
+	util.StartupProfile.Mark("read config")
+}
+
+// cachedView wraps the run of an expensive view to print its cached output while the
+// sync token, the arguments and the flags are the same. A selection is never cached.
+// The view renders from the client which addresses the cache.
+func cachedView(run func(cmd *cobra.Command, args []string, client *todoist.Client) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if f := cmd.Flags().Lookup("select"); f != nil && f.Value.String() == "true" {
+			return run(cmd, args, client)
+		}
+		parts := append([]string{cmd.CommandPath()}, args...)
+		cmd.Flags().Visit(func(f *pflag.Flag) {
+			parts = append(parts, "--"+f.Name+"="+f.Value.String())
+		})
+		return util.CachedView(client, parts, func() error {
+			return run(cmd, args, client)
+		})
 	}
 }
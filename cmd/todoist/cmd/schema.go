@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema [name]",
+	Short: "print the json schema of a json output",
+	Long: fmt.Sprintf(`Print the JSON Schema of the resources printed by --output json, one of %s.
+Without a name, the names and the version of the schemas are listed.`, strings.Join(util.SchemaNames(), ", ")),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			fmt.Printf("version %d\n", util.SchemaVersion)
+			for _, name := range util.SchemaNames() {
+				fmt.Println(name)
+			}
+			return nil
+		}
+		schema, err := util.Schema(args[0])
+		if err != nil {
+			return err
+		}
+		b, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(schemaCmd)
+}
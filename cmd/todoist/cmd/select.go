@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"time"
+)
+
+const selectFlagUsage = "select items to complete, delete, move, label or reschedule them at once"
+
+// runSelect lets the user check items of a list, then applies one action
+// to all of them and commits it in one batch.
+func runSelect(client *todoist.Client, items []todoist.Item, relations todoist.ItemRelations) error {
+	if len(items) == 0 {
+		fmt.Println("no items to select")
+		return nil
+	}
+	selected, err := util.SelectItems(items, relations)
+	if err == util.ErrAbort {
+		fmt.Println("abort")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Println("no items selected")
+		return nil
+	}
+	action, _ := util.Prompt(fmt.Sprintf("action for %d item(s) (complete, delete, move, label, due)", len(selected)))
+	verbs := map[string]string{"complete": "complete", "delete": "delete", "move": "move", "label": "label", "due": "reschedule"}
+	if _, ok := verbs[action]; !ok {
+		return fmt.Errorf("unknown action: %s", action)
+	}
+	if !util.ConfirmBulk(verbs[action], selected, relations) {
+		fmt.Println("abort")
+		return nil
+	}
+	switch action {
+	case "complete":
+		date := todoist.Time{Time: time.Now().UTC()}
+		for _, i := range selected {
+			if err = util.SavePosition(i); err != nil {
+				return err
+			}
+			if err = client.Item.Complete(i.ID, date, true); err != nil {
+				return err
+			}
+		}
+	case "delete":
+		for _, i := range selected {
+			if err = client.Item.Delete(i.ID); err != nil {
+				return err
+			}
+		}
+	case "move":
+		project, _ := util.Prompt("project id or name")
+		opts := &todoist.ItemMoveOpts{}
+		if opts.ProjectID, err = util.ResolveProjectID(client, project); err != nil {
+			return err
+		}
+		if section, _ := util.Prompt("section id or name (empty: none)"); len(section) != 0 {
+			// the section determines the project
+			if opts.SectionID, err = util.ResolveSectionID(client, opts.ProjectID, section); err != nil {
+				return err
+			}
+			opts.ProjectID = ""
+		}
+		for _, i := range selected {
+			if err = client.Item.Move(i.ID, opts); err != nil {
+				return err
+			}
+		}
+	case "label":
+		name, _ := util.Prompt("label name")
+		label := client.Label.FindOneByName(name)
+		if label == nil {
+			return fmt.Errorf("no such label: %s", name)
+		}
+		for _, i := range selected {
+			if util.HasLabel(i, label.ID) {
+				continue
+			}
+			if err = client.Item.UpdateLabels(i.ID, append(i.Labels, label.ID)); err != nil {
+				return err
+			}
+		}
+	case "due":
+		date, err := util.PickDate("due date", selected[0].Due.Date.Local().Time)
+		if err != nil {
+			return err
+		}
+		if date.IsZero() {
+			fmt.Println("no date picked")
+			return nil
+		}
+		for _, i := range selected {
+			util.SetDueDate(&i, date)
+			if _, err = client.Item.Update(i); err != nil {
+				return err
+			}
+		}
+	}
+	ctx := context.Background()
+	if err = client.Commit(ctx); err != nil {
+		return err
+	}
+	if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+		return err
+	}
+	fmt.Printf("succeeded to %s %d item(s)\n", action, len(selected))
+	return nil
+}
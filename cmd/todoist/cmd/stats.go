@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "subcommand for statistics of completed items",
+}
+
+var statsLabelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "show completion counts and average days to complete per label",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sinceStr, err := cmd.Flags().GetString("since")
+		if err != nil {
+			return err
+		}
+		since, err := util.ParseSince(sinceStr, time.Now())
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		completed, err := client.Completed.GetAllPages(ctx, &todoist.CompletedGetAllOpts{
+			Since:         todoist.Time{Time: since},
+			AnnotateItems: true,
+		})
+		if err != nil {
+			return err
+		}
+		// the completed archive has neither labels nor added dates,
+		// so they are taken from the annotated item objects, or from the cache.
+		items := map[todoist.ID]todoist.Item{}
+		for _, c := range completed.Items {
+			if c.TaskID.IsZero() {
+				continue
+			}
+			if c.ItemObject != nil {
+				items[c.TaskID] = *c.ItemObject
+			} else if item := client.Item.Resolve(c.TaskID); item != nil {
+				items[c.TaskID] = *item
+			}
+		}
+		stats := util.NewLabelStats(completed.Items, items, client.Label.GetAll())
+		fmt.Println(util.LabelStatTableString(stats))
+		return nil
+	},
+}
+
+var statsTrendCmd = &cobra.Command{
+	Use:   "trend",
+	Short: "show items created and completed per week with a forecast of the backlog",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		weeks, err := cmd.Flags().GetInt("weeks")
+		if err != nil {
+			return err
+		}
+		forecast, err := cmd.Flags().GetInt("forecast")
+		if err != nil {
+			return err
+		}
+		if weeks <= 0 {
+			return errors.New("--weeks must be positive")
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		since := now.AddDate(0, 0, -7*weeks)
+		ctx := context.Background()
+		var events []todoist.Event
+		for _, eventType := range []string{"added", "completed"} {
+			e, err := client.Activity.GetAllPages(ctx, &todoist.ActivityGetOpts{
+				ObjectType: "item",
+				EventType:  eventType,
+				Since:      todoist.Time{Time: since},
+			})
+			if err != nil {
+				return err
+			}
+			events = append(events, e...)
+		}
+		backlog := 0
+		for _, i := range client.Item.GetAll() {
+			if !i.IsChecked() {
+				backlog++
+			}
+		}
+		trend := util.NewTrend(events, weeks, now, backlog, forecast)
+		if err = util.Print(util.TrendOutput(trend)); err != nil {
+			return err
+		}
+		if util.OutputFormat == "table" {
+			fmt.Println(trend)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(statsCmd)
+	statsLabelsCmd.Flags().String("since", "1m", "period to count, such as 10d, 2w, 3m, 1y or 2019-03-10")
+	statsCmd.AddCommand(statsLabelsCmd)
+	statsTrendCmd.Flags().Int("weeks", 12, "number of weeks to show")
+	statsTrendCmd.Flags().Int("forecast", 4, "number of weeks to forecast the backlog")
+	statsCmd.AddCommand(statsTrendCmd)
+}
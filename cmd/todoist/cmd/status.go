@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show the number of today's tasks for status bars",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		widget, err := cmd.Flags().GetString("widget")
+		if err != nil {
+			return err
+		}
+		short, err := cmd.Flags().GetBool("short")
+		if err != nil {
+			return err
+		}
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		status := util.NewStatus(client.Item.GetAll(), 3)
+		switch widget {
+		case "":
+			if short {
+				fmt.Println(status.Due)
+			} else {
+				fmt.Println(status)
+			}
+		case "waybar":
+			s, err := status.WaybarString()
+			if err != nil {
+				return err
+			}
+			fmt.Println(s)
+		case "i3blocks":
+			fmt.Println(status.I3blocksString())
+			if status.Urgent() {
+				// i3blocks shows the block as urgent by the exit code
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return exitError{code: 33}
+			}
+		case "tmux":
+			fmt.Println(status.TmuxString())
+		default:
+			return fmt.Errorf("unknown widget: %s", widget)
+		}
+		return nil
+	},
+}
+
+func init() {
+	statusCmd.Flags().StringP("widget", "w", "", "status bar format (waybar, i3blocks, tmux)")
+	statusCmd.Flags().Bool("short", false, "print the number only")
+	RootCmd.AddCommand(statusCmd)
+}
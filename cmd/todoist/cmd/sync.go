@@ -6,18 +6,49 @@ import (
 	"github.com/kobtea/go-todoist/cmd/util"
 	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 // syncCmd represents the sync command
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Syncronize origin server",
+	Long: `Syncronize origin server.
+
+In metered mode, enabled by --metered or the metered section of the config,
+it syncs only the changes since the last sync, and skips syncing within the
+min_interval of the config:
+
+  "metered": {"enabled": true, "min_interval": "30m", "exclude": ["notes", "collaborators"]}`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return err
+		}
 		client, err := util.NewClient()
 		if err != nil {
 			return err
 		}
-		if err = client.FullSync(context.Background(), []todoist.Command{}); err != nil {
+		ctx := context.Background()
+		if p, err := client.Pending(); err != nil {
+			return err
+		} else if p != nil {
+			if err = client.RetryPending(ctx); err != nil {
+				return fmt.Errorf("failed to retry %d unsent command(s): %s", len(p.Commands), err)
+			}
+			fmt.Printf("succeeded to retry %d unsent command(s)\n", len(p.Commands))
+		}
+		if util.Metered.Enabled && !force {
+			interval, err := util.Metered.Interval()
+			if err != nil {
+				return err
+			}
+			if at := client.SyncedAt(); time.Since(at) < interval {
+				fmt.Printf("synced at %s, skip syncing within %s on the metered connection (use --force)\n", at.Format("15:04"), interval)
+				return nil
+			}
+		}
+		if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
 			return err
 		}
 		fmt.Printf("update sync token: %s", client.SyncToken)
@@ -25,6 +56,46 @@ var syncCmd = &cobra.Command{
 	},
 }
 
+var syncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show the last sync, unsent commands and the rate limit budget",
+	Long: `Show the last sync, unsent commands and the rate limit budget.
+
+The daemon and the commands run with the same token share a budget of requests.
+The daemon leaves the reserve of each window to the commands, and all of them
+wait while the budget is used up:
+
+  "rate_limit": {"requests": 50, "window": "1m", "reserve": 10}`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		if at := client.SyncedAt(); at.IsZero() {
+			fmt.Println("synced: never")
+		} else {
+			fmt.Printf("synced: %s\n", at.Format(time.RFC3339))
+		}
+		p, err := client.Pending()
+		if err != nil {
+			return err
+		}
+		n := 0
+		if p != nil {
+			n = len(p.Commands)
+		}
+		fmt.Printf("unsent: %d command(s)\n", n)
+		budget, err := util.ReadBudget(client.Token)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("budget: %s\n", budget)
+		return nil
+	},
+}
+
 func init() {
+	syncCmd.AddCommand(syncStatusCmd)
+	syncCmd.Flags().Bool("force", false, "sync within the min interval of the metered mode")
 	RootCmd.AddCommand(syncCmd)
 }
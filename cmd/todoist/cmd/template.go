@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/cmd/util"
+	"github.com/kobtea/go-todoist/todoist"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+)
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "subcommand for project templates",
+	Long: `Export a project as a yaml template, apply a template as a new project,
+and share templates through the backend configured in the template section of the config:
+
+  "template": {"backend": "gist", "token": "<github token>"}
+  "template": {"backend": "paste", "url": "https://paste.example.com/"}`,
+}
+
+var templateExportCmd = &cobra.Command{
+	Use:   "export [project]",
+	Short: "print a project as a template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := util.NewClient()
+		if err != nil {
+			return err
+		}
+		projectID, err := util.ResolveProjectID(client, args[0])
+		if err != nil {
+			return err
+		}
+		t, err := util.NewTemplate(client, projectID)
+		if err != nil {
+			return err
+		}
+		b, err := t.YAML()
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(b))
+		return nil
+	},
+}
+
+var templateApplyCmd = &cobra.Command{
+	Use:   "apply FILE",
+	Short: "add a project from a template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		return applyTemplate(cmd, b)
+	},
+}
+
+var templatePublishCmd = &cobra.Command{
+	Use:   "publish FILE",
+	Short: "upload a template and print the url",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		t, err := util.ParseTemplate(b)
+		if err != nil {
+			return err
+		}
+		url, err := util.PublishTemplate(templateConfig(), t.Name, b)
+		if err != nil {
+			return err
+		}
+		fmt.Println(url)
+		return nil
+	},
+}
+
+var templateFetchCmd = &cobra.Command{
+	Use:   "fetch URL",
+	Short: "download a template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apply, err := cmd.Flags().GetBool("apply")
+		if err != nil {
+			return err
+		}
+		b, err := util.FetchTemplate(templateConfig(), args[0])
+		if err != nil {
+			return err
+		}
+		if apply {
+			return applyTemplate(cmd, b)
+		}
+		if _, err = util.ParseTemplate(b); err != nil {
+			return err
+		}
+		fmt.Print(string(b))
+		return nil
+	},
+}
+
+// templateConfig returns the template backend of the config, which is empty without a config.
+func templateConfig() util.TemplateConfig {
+	if config, err := util.LoadConfig(); err == nil {
+		return config.Template
+	}
+	return util.TemplateConfig{}
+}
+
+func applyTemplate(cmd *cobra.Command, b []byte) error {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+	t, err := util.ParseTemplate(b)
+	if err != nil {
+		return err
+	}
+	client, err := util.NewClient()
+	if err != nil {
+		return err
+	}
+	project, err := t.Queue(client, name)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err = client.Commit(ctx); err != nil {
+		return err
+	}
+	if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+		return err
+	}
+	projects := client.Project.FindByName(project.Name)
+	if len(projects) == 0 {
+		return errors.New("failed to add the project. it may be failed to sync")
+	}
+	fmt.Println("succeeded to add a project from the template")
+	fmt.Println(util.ProjectTableString([]todoist.Project{projects[len(projects)-1]}))
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateExportCmd)
+	templateApplyCmd.Flags().String("name", "", "name of the new project (default: name of the template)")
+	templateCmd.AddCommand(templateApplyCmd)
+	templateCmd.AddCommand(templatePublishCmd)
+	templateFetchCmd.Flags().Bool("apply", false, "add a project from the template instead of printing it")
+	templateFetchCmd.Flags().String("name", "", "name of the new project with --apply (default: name of the template)")
+	templateCmd.AddCommand(templateFetchCmd)
+}
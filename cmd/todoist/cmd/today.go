@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"github.com/kobtea/go-todoist/cmd/util"
 	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/cobra"
@@ -12,8 +11,8 @@ import (
 var todayCmd = &cobra.Command{
 	Use:   "today",
 	Short: "show today's tasks",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := util.NewClient()
+	RunE: cachedView(func(cmd *cobra.Command, args []string, client *todoist.Client) error {
+		selectMode, err := cmd.Flags().GetBool("select")
 		if err != nil {
 			return err
 		}
@@ -24,14 +23,20 @@ var todayCmd = &cobra.Command{
 			}
 		}
 		sort.Slice(items, func(i, j int) bool {
-			return items[i].Due.Date.Before(items[j].Due.Date)
+			return items[i].Due.Date.Local().Before(items[j].Due.Date.Local())
 		})
+		if items, err = util.ApplyFocus(client, items); err != nil {
+			return err
+		}
 		relations := client.Relation.Items(items)
-		fmt.Println(util.ItemTableString(items, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
-		return nil
-	},
+		if selectMode {
+			return runSelect(client, items, relations)
+		}
+		return util.Print(util.ItemsOutput(items, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+	}),
 }
 
 func init() {
+	todayCmd.Flags().Bool("select", false, selectFlagUsage)
 	RootCmd.AddCommand(todayCmd)
 }
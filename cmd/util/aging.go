@@ -0,0 +1,92 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsePriority parses a priority like `p1`, the highest, into the priority of the api, 4 for p1.
+func ParsePriority(s string) (int, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	if len(lower) != 2 || lower[0] != 'p' || lower[1] < '1' || '4' < lower[1] {
+		return 0, fmt.Errorf("invalid priority: %s, choose one of p1, p2, p3 or p4", s)
+	}
+	p, _ := strconv.Atoi(lower[1:])
+	return 5 - p, nil
+}
+
+// FormatPriority formats a priority of the api like `p1`. No priority is p4.
+func FormatPriority(priority int) string {
+	if priority < 1 {
+		priority = 1
+	}
+	return fmt.Sprintf("p%d", 5-priority)
+}
+
+// PriorityAging is an item which has stayed at a low priority for long, with the priority to escalate it to.
+type PriorityAging struct {
+	Item      todoist.Item `json:"item"`
+	Days      int          `json:"days"`
+	Suggested int          `json:"suggested"`
+}
+
+// NewPriorityAgings returns the unchecked items added before since with a lower priority than suggested,
+// from the oldest. A suggested priority of 0 escalates each item by one level.
+func NewPriorityAgings(items []todoist.Item, since time.Time, suggested int, now time.Time) []PriorityAging {
+	var res []PriorityAging
+	for _, i := range items {
+		if i.IsChecked() || i.DateAdded.IsZero() || !i.DateAdded.Time.Before(since) {
+			continue
+		}
+		current := i.Priority
+		if current < 1 {
+			current = 1
+		}
+		to := suggested
+		if to == 0 {
+			to = current + 1
+		}
+		if current >= to || to > 4 {
+			continue
+		}
+		res = append(res, PriorityAging{
+			Item:      i,
+			Days:      int(now.Sub(i.DateAdded.Time).Hours() / 24),
+			Suggested: to,
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Item.DateAdded.Before(res[j].Item.DateAdded)
+	})
+	return res
+}
+
+// PriorityAgingOutput has a row for each item with its age in days and the escalation like `p4 -> p2`.
+func PriorityAgingOutput(agings []PriorityAging, relations todoist.ItemRelations) Output {
+	if agings == nil {
+		agings = []PriorityAging{}
+	}
+	var rows [][]todoist.ColorStringer
+	for _, a := range agings {
+		project := ""
+		if p, ok := relations.Projects[a.Item.ProjectID]; ok {
+			project = p.String()
+		}
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(a.Item.ID.String()),
+			todoist.NewNoColorString(fmt.Sprintf("%dd", a.Days)),
+			todoist.NewNoColorString(fmt.Sprintf("%s -> %s", FormatPriority(a.Item.Priority), FormatPriority(a.Suggested))),
+			todoist.NewNoColorString(project),
+			todoist.NewNoColorString(a.Item.Content),
+		})
+	}
+	return Output{
+		Columns: []string{"id", "age", "priority", "project", "content"},
+		Rows:    rows,
+		Data:    agings,
+	}
+}
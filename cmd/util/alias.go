@@ -0,0 +1,42 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const aliasesState = "aliases"
+
+// SaveItemAliases numbers the listed items from 1 so that later commands accept `#N`.
+func SaveItemAliases(items []todoist.Item) error {
+	var ids []todoist.ID
+	for _, i := range items {
+		ids = append(ids, i.ID)
+	}
+	return WriteState(aliasesState, ids)
+}
+
+// ParseItemID accepts an item id or a `#N` alias from the last item listing.
+func ParseItemID(s string) (todoist.ID, error) {
+	if !strings.HasPrefix(s, "#") {
+		return todoist.NewID(s)
+	}
+	n, err := strconv.Atoi(s[1:])
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("invalid alias: %s", s)
+	}
+	var ids []todoist.ID
+	if err = ReadState(aliasesState, &ids); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("unknown alias: %s, run `todoist item list` first", s)
+		}
+		return "", err
+	}
+	if n > len(ids) {
+		return "", fmt.Errorf("unknown alias: %s", s)
+	}
+	return ids[n-1], nil
+}
@@ -0,0 +1,163 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ParseSize parses a size like `25MB`, `512KB` or `1048576` into bytes.
+func ParseSize(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+	for _, u := range []struct {
+		suffix string
+		n      int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(str, u.suffix) {
+			str, unit = strings.TrimSpace(strings.TrimSuffix(str, u.suffix)), u.n
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(str, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size: %s", s)
+	}
+	return int64(n * float64(unit)), nil
+}
+
+// File is the content of a file to upload.
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// ReadFile reads a local file to upload, refusing files larger than limit bytes.
+func ReadFile(name string, limit int64) (*File, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > limit {
+		return nil, fmt.Errorf("%s is larger than %s", name, formatSize(limit))
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Name: filepath.Base(name), ContentType: contentType(name, "", b), Data: b}, nil
+}
+
+// DownloadFile fetches a remote file to upload, refusing files larger than limit bytes.
+// The name and the type are taken from the response, or detected from the url and the content.
+func DownloadFile(ctx context.Context, rawurl string, limit int64) (*File, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid url: %s", rawurl)
+	}
+	req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if (res.StatusCode / 100) != 2 {
+		return nil, fmt.Errorf("failed to download %s: %s", rawurl, res.Status)
+	}
+	if res.ContentLength > limit {
+		return nil, fmt.Errorf("%s is larger than %s", rawurl, formatSize(limit))
+	}
+	// one more byte tells a file over the limit without a content length
+	b, err := ioutil.ReadAll(io.LimitReader(res.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > limit {
+		return nil, fmt.Errorf("%s is larger than %s", rawurl, formatSize(limit))
+	}
+	name := remoteFileName(u, res.Header)
+	return &File{Name: name, ContentType: contentType(name, res.Header.Get("Content-Type"), b), Data: b}, nil
+}
+
+// LinkFile returns an attachment which refers to the remote file instead of uploading it.
+// The name, the type and the size are taken from a HEAD request, when the server answers it.
+func LinkFile(ctx context.Context, rawurl string) (*todoist.FileAttachment, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid url: %s", rawurl)
+	}
+	a := &todoist.FileAttachment{FileURL: rawurl, FileName: remoteFileName(u, nil), UploadState: "completed"}
+	req, err := http.NewRequest(http.MethodHead, rawurl, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	if (res.StatusCode / 100) == 2 {
+		a.FileName = remoteFileName(u, res.Header)
+		a.FileType = contentType(a.FileName, res.Header.Get("Content-Type"), nil)
+		if res.ContentLength > 0 {
+			a.FileSize = int(res.ContentLength)
+		}
+	}
+	return a, nil
+}
+
+func remoteFileName(u *url.URL, header http.Header) string {
+	if header != nil {
+		if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil && len(params["filename"]) != 0 {
+			return filepath.Base(params["filename"])
+		}
+	}
+	if name := path.Base(u.Path); name != "/" && name != "." {
+		return name
+	}
+	return u.Host
+}
+
+// contentType prefers the type of the server unless it is generic, then the extension, then the content.
+func contentType(name, header string, b []byte) string {
+	if t, _, err := mime.ParseMediaType(header); err == nil && t != "application/octet-stream" {
+		return t
+	}
+	if t := mime.TypeByExtension(filepath.Ext(name)); len(t) != 0 {
+		return t
+	}
+	if len(b) != 0 {
+		return http.DetectContentType(b)
+	}
+	return "application/octet-stream"
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.0fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.0fKB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// Upload uploads the file and returns the attachment for a new note.
+func (f File) Upload(ctx context.Context, client *todoist.Client) (*todoist.FileAttachment, error) {
+	return client.Upload.Add(ctx, f.Name, f.ContentType, bytes.NewReader(f.Data))
+}
@@ -0,0 +1,259 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const backupLayout = "20060102T150405"
+
+// Backup is a full json export of the state, kept under the backups directory.
+type Backup struct {
+	Name string
+	Time time.Time
+	Size int64
+}
+
+func backupDir() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "backups")
+	if _, err := os.Stat(dir); err != nil {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+func WriteBackup(state todoist.SyncState) (*Backup, error) {
+	dir, err := backupDir()
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	name := now.Format(backupLayout)
+	if err = ioutil.WriteFile(filepath.Join(dir, name+".json"), b, 0600); err != nil {
+		return nil, err
+	}
+	return &Backup{Name: name, Time: now, Size: int64(len(b))}, nil
+}
+
+// ListBackups returns the backups from the oldest.
+func ListBackups() ([]Backup, error) {
+	dir, err := backupDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []Backup
+	for _, f := range files {
+		name := strings.TrimSuffix(f.Name(), ".json")
+		t, err := time.ParseInLocation(backupLayout, name, time.Local)
+		if err != nil || f.IsDir() {
+			continue
+		}
+		res = append(res, Backup{Name: name, Time: t, Size: f.Size()})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Time.Before(res[j].Time)
+	})
+	return res, nil
+}
+
+func ReadBackup(name string) (*todoist.SyncState, error) {
+	dir, err := backupDir()
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, strings.TrimSuffix(name, ".json")+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no such backup: %s", name)
+		}
+		return nil, err
+	}
+	var state todoist.SyncState
+	if err = json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// PruneBackups removes the oldest backups to keep at most retention backups.
+func PruneBackups(retention int) error {
+	if retention <= 0 {
+		return nil
+	}
+	backups, err := ListBackups()
+	if err != nil {
+		return err
+	}
+	dir, err := backupDir()
+	if err != nil {
+		return err
+	}
+	for len(backups) > retention {
+		if err = os.Remove(filepath.Join(dir, backups[0].Name+".json")); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+func BackupTableString(backups []Backup) string {
+	var rows [][]todoist.ColorStringer
+	for _, b := range backups {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(b.Name),
+			todoist.NewNoColorString(todoist.Time{Time: b.Time}.String()),
+			todoist.NewNoColorString(fmt.Sprintf("%dKB", (b.Size+1023)/1024)),
+		})
+	}
+	return TableString(rows)
+}
+
+// BackupRestore is the part of a backup which no longer exists.
+type BackupRestore struct {
+	Projects []todoist.Project
+	Labels   []todoist.Label
+	Items    []todoist.Item
+}
+
+func NewBackupRestore(client *todoist.Client, backup *todoist.SyncState) BackupRestore {
+	var res BackupRestore
+	changes := todoist.DiffStates(*backup, client.Snapshot())
+	for _, p := range changes.Projects.Removed {
+		if !p.InboxProject {
+			res.Projects = append(res.Projects, p)
+		}
+	}
+	res.Labels = changes.Labels.Removed
+	for _, i := range changes.Items.Removed {
+		if !i.IsChecked() {
+			res.Items = append(res.Items, i)
+		}
+	}
+	return res
+}
+
+func (r BackupRestore) IsEmpty() bool {
+	return len(r.Projects)+len(r.Labels)+len(r.Items) == 0
+}
+
+// Queue adds the missing resources again. References between them are
+// re-linked to the new temp ids, so parents are added before their children.
+func (r BackupRestore) Queue(client *todoist.Client) error {
+	ids := map[todoist.ID]todoist.ID{}
+	link := func(id todoist.ID) todoist.ID {
+		if newID, ok := ids[id]; ok {
+			return newID
+		}
+		return id
+	}
+	for _, l := range r.Labels {
+		label, err := todoist.NewLabel(l.Name, &todoist.NewLabelOpts{Color: l.Color, ItemOrder: l.ItemOrder, IsFavorite: l.IsFavorite})
+		if err != nil {
+			return err
+		}
+		if _, err = client.Label.Add(*label); err != nil {
+			return err
+		}
+		ids[l.ID] = label.ID
+	}
+	pending := r.Projects
+	for len(pending) > 0 {
+		var rest []todoist.Project
+		for _, p := range pending {
+			if isPendingParent(p.ParentID, pending) {
+				rest = append(rest, p)
+				continue
+			}
+			project, err := todoist.NewProject(p.Name, &todoist.NewProjectOpts{
+				Color:      p.Color,
+				ParentID:   link(p.ParentID),
+				ChildOrder: p.ChildOrder,
+				IsFavorite: p.IsFavorite,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err = client.Project.Add(*project); err != nil {
+				return err
+			}
+			ids[p.ID] = project.ID
+		}
+		if len(rest) == len(pending) {
+			return fmt.Errorf("failed to resolve parents of %d project(s)", len(rest))
+		}
+		pending = rest
+	}
+	items := r.Items
+	for len(items) > 0 {
+		var rest []todoist.Item
+		for _, i := range items {
+			if isPendingItem(i.ParentID, items) {
+				rest = append(rest, i)
+				continue
+			}
+			item := todoist.Item{
+				ProjectID: link(i.ProjectID),
+				ParentID:  link(i.ParentID),
+				Content:   i.Content,
+				Due:       i.Due,
+				Priority:  i.Priority,
+			}
+			if client.Section.Resolve(i.SectionID) != nil {
+				item.SectionID = i.SectionID
+			}
+			for _, lid := range i.Labels {
+				item.Labels = append(item.Labels, link(lid))
+			}
+			added, err := client.Item.Add(item)
+			if err != nil {
+				return err
+			}
+			ids[i.ID] = added.ID
+		}
+		if len(rest) == len(items) {
+			return fmt.Errorf("failed to resolve parents of %d item(s)", len(rest))
+		}
+		items = rest
+	}
+	return nil
+}
+
+func isPendingParent(id todoist.ID, projects []todoist.Project) bool {
+	for _, p := range projects {
+		if !id.IsZero() && p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func isPendingItem(id todoist.ID, items []todoist.Item) bool {
+	for _, i := range items {
+		if !id.IsZero() && i.ID == id {
+			return true
+		}
+	}
+	return false
+}
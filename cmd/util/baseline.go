@@ -0,0 +1,136 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+	"regexp"
+	"time"
+)
+
+// Baseline is a snapshot saved under a name, such as monday, to review what has changed since.
+type Baseline struct {
+	Name  string            `json:"name"`
+	Time  time.Time         `json:"time"`
+	State todoist.SyncState `json:"state"`
+}
+
+var baselineName = regexp.MustCompile(`^[\w-]+$`)
+
+func baselineState(name string) (string, error) {
+	if !baselineName.MatchString(name) {
+		return "", fmt.Errorf("invalid baseline name: %s", name)
+	}
+	return "baseline-" + name, nil
+}
+
+// SaveBaseline keeps the state as the baseline of the name, replacing the previous one.
+func SaveBaseline(name string, state todoist.SyncState) (*Baseline, error) {
+	key, err := baselineState(name)
+	if err != nil {
+		return nil, err
+	}
+	b := Baseline{Name: name, Time: time.Now(), State: state}
+	if err = WriteState(key, b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// ReadBaseline returns the baseline of the name.
+func ReadBaseline(name string) (*Baseline, error) {
+	key, err := baselineState(name)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err = ReadState(key, &b); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no baseline %s, save it by `todoist baseline save %s`", name, name)
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+// BaselineDiff are the items added, completed, rescheduled and removed since a baseline.
+type BaselineDiff struct {
+	Added       []todoist.Item       `json:"added"`
+	Completed   []todoist.Item       `json:"completed"`
+	Rescheduled []todoist.ItemChange `json:"rescheduled"`
+	Removed     []todoist.Item       `json:"removed"`
+}
+
+// NewBaselineDiff compares the items of the baseline with the current state. Items which have left the
+// state are completed when completed has their id, such as the completed archive since the baseline,
+// and removed otherwise. The completed cycles of recurring items are completed rather than rescheduled.
+func NewBaselineDiff(base, current todoist.SyncState, completed map[todoist.ID]bool) BaselineDiff {
+	var d BaselineDiff
+	changes := todoist.DiffStates(base, current).Items
+	for _, i := range changes.Added {
+		if i.IsChecked() {
+			d.Completed = append(d.Completed, i)
+		} else {
+			d.Added = append(d.Added, i)
+		}
+	}
+	for _, c := range changes.Modified {
+		switch {
+		case c.After.IsChecked() && !c.Before.IsChecked():
+			d.Completed = append(d.Completed, c.After)
+		case completed[c.After.ID]:
+			d.Completed = append(d.Completed, c.After)
+		case !c.Before.Due.Date.Equal(c.After.Due.Date):
+			d.Rescheduled = append(d.Rescheduled, c)
+		}
+	}
+	for _, i := range changes.Removed {
+		if completed[i.ID] {
+			d.Completed = append(d.Completed, i)
+		} else {
+			d.Removed = append(d.Removed, i)
+		}
+	}
+	return d
+}
+
+// IsEmpty reports whether nothing has changed since the baseline.
+func (d BaselineDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Completed) == 0 && len(d.Rescheduled) == 0 && len(d.Removed) == 0
+}
+
+// BaselineDiffOutput has a row for each changed item, marked by +, x, > or - for added, completed,
+// rescheduled and removed. The due of rescheduled items is shown as `before -> after`.
+func BaselineDiffOutput(d BaselineDiff, relations todoist.ItemRelations) Output {
+	var rows [][]todoist.ColorStringer
+	add := func(mark string, item todoist.Item, due string) {
+		project := ""
+		if p, ok := relations.Projects[item.ProjectID]; ok {
+			project = p.String()
+		}
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(mark),
+			todoist.NewNoColorString(item.ID.String()),
+			todoist.NewNoColorString(due),
+			todoist.NewNoColorString(project),
+			todoist.NewNoColorString(item.Content),
+		})
+	}
+	for _, i := range d.Added {
+		add("+", i, i.Due.Date.String())
+	}
+	for _, i := range d.Completed {
+		add("x", i, i.Due.Date.String())
+	}
+	for _, c := range d.Rescheduled {
+		add(">", c.After, fmt.Sprintf("%s -> %s", c.Before.Due.Date, c.After.Due.Date))
+	}
+	for _, i := range d.Removed {
+		add("-", i, i.Due.Date.String())
+	}
+	return Output{
+		Columns: []string{"change", "id", "date", "project", "content"},
+		Rows:    rows,
+		Data:    d,
+	}
+}
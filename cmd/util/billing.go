@@ -0,0 +1,91 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// trackedNote is a time tracking note, such as `tracked 1h30m` or `spent: 45m`.
+var trackedNote = regexp.MustCompile(`^(?i:tracked|spent|time):?\s+(\S+)`)
+
+// TrackedTime returns the duration of the item, or the sum of its time tracking notes without a duration.
+func TrackedTime(item todoist.Item, notes []todoist.Note) time.Duration {
+	if item.Duration != nil {
+		if d := item.Duration.Duration(); d > 0 {
+			return d
+		}
+	}
+	var sum time.Duration
+	for _, n := range notes {
+		m := trackedNote.FindStringSubmatch(strings.TrimSpace(n.Content))
+		if m == nil {
+			continue
+		}
+		if d, err := time.ParseDuration(m[1]); err == nil && d > 0 {
+			sum += d
+		}
+	}
+	return sum
+}
+
+// BillingEntry is a completed item with its tracked time.
+type BillingEntry struct {
+	Item      todoist.Item  `json:"item"`
+	Project   string        `json:"project"`
+	Completed time.Time     `json:"completed"`
+	Tracked   time.Duration `json:"tracked"`
+	Hours     float64       `json:"hours"`
+	Amount    float64       `json:"amount"`
+}
+
+// Billing is the billing of completed items at an hourly rate.
+type Billing struct {
+	Rate    float64        `json:"rate"`
+	Entries []BillingEntry `json:"entries"`
+	Hours   float64        `json:"hours"`
+	Amount  float64        `json:"amount"`
+}
+
+// NewBilling charges the entries at rate per hour.
+func NewBilling(entries []BillingEntry, rate float64) Billing {
+	b := Billing{Rate: rate, Entries: []BillingEntry{}}
+	for _, e := range entries {
+		e.Hours = e.Tracked.Hours()
+		e.Amount = e.Hours * rate
+		b.Hours += e.Hours
+		b.Amount += e.Amount
+		b.Entries = append(b.Entries, e)
+	}
+	return b
+}
+
+// BillingOutput has a row for each entry and a row of the totals.
+func BillingOutput(b Billing) Output {
+	var rows [][]todoist.ColorStringer
+	for _, e := range b.Entries {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(e.Completed.Local().Format("2006-01-02 15:04")),
+			todoist.NewNoColorString(e.Item.TaskID.String()),
+			todoist.NewNoColorString(e.Project),
+			todoist.NewNoColorString(e.Item.Content),
+			todoist.NewNoColorString(fmt.Sprintf("%.2f", e.Hours)),
+			todoist.NewNoColorString(fmt.Sprintf("%.2f", e.Amount)),
+		})
+	}
+	rows = append(rows, []todoist.ColorStringer{
+		todoist.NewNoColorString("total"),
+		todoist.NewNoColorString(""),
+		todoist.NewNoColorString(""),
+		todoist.NewNoColorString(fmt.Sprintf("%d tasks", len(b.Entries))),
+		todoist.NewNoColorString(fmt.Sprintf("%.2f", b.Hours)),
+		todoist.NewNoColorString(fmt.Sprintf("%.2f", b.Amount)),
+	})
+	return Output{
+		Columns: []string{"completed", "id", "project", "content", "hours", "amount"},
+		Rows:    rows,
+		Data:    b,
+	}
+}
@@ -0,0 +1,67 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"strconv"
+	"strings"
+)
+
+// BulkConfig guards the commands which change many items at once.
+type BulkConfig struct {
+	// Threshold is the number of items above which the count has to be typed to confirm, 10 by default.
+	Threshold int `json:"threshold,omitempty"`
+	// Preview is the most items shown before the confirmation, 20 by default.
+	Preview int `json:"preview,omitempty"`
+}
+
+// Bulk is the bulk config of this run.
+var Bulk BulkConfig
+
+func (c BulkConfig) threshold() int {
+	if c.Threshold <= 0 {
+		return 10
+	}
+	return c.Threshold
+}
+
+func (c BulkConfig) preview() int {
+	if c.Preview <= 0 {
+		return 20
+	}
+	return c.Preview
+}
+
+// BulkPreview returns how many of n entities are shown before a confirmation.
+func BulkPreview(n int) int {
+	if n > Bulk.preview() {
+		return Bulk.preview()
+	}
+	return n
+}
+
+// PrintBulkItems shows the items up to the preview limit.
+func PrintBulkItems(items []todoist.Item, relations todoist.ItemRelations) {
+	preview := items[:BulkPreview(len(items))]
+	fmt.Println(ItemTableString(preview, relations, func(i todoist.Item) todoist.Time { return i.Due.Date }))
+	if rest := len(items) - len(preview); rest > 0 {
+		fmt.Printf("... and %d more item(s)\n", rest)
+	}
+}
+
+// ConfirmBulk shows the items up to the preview limit and asks to confirm the action, such as `delete`.
+// Above the threshold, the number of the items has to be typed instead of yes.
+func ConfirmBulk(action string, items []todoist.Item, relations todoist.ItemRelations) bool {
+	PrintBulkItems(items, relations)
+	return ConfirmBulkCount(action, fmt.Sprintf("%d item(s)", len(items)), len(items))
+}
+
+// ConfirmBulkCount asks to confirm the action on n entities described by what, such as `2 project(s) and 5 item(s)`.
+// Above the threshold, n has to be typed instead of yes.
+func ConfirmBulkCount(action, what string, n int) bool {
+	if n <= Bulk.threshold() {
+		return Confirm(fmt.Sprintf("are you sure to %s above %s?", action, what))
+	}
+	ans, ok := Prompt(fmt.Sprintf("%s %s? type %d to confirm", action, what, n))
+	return ok && strings.TrimSpace(ans) == strconv.Itoa(n)
+}
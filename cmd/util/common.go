@@ -3,39 +3,281 @@ package util
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/fatih/color"
 	"github.com/kobtea/go-todoist/todoist"
 	"github.com/spf13/viper"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"time"
 )
 
 type Config struct {
+	Token  string       `json:"token"`
+	SMTP   SMTPConfig   `json:"smtp"`
+	Daemon DaemonConfig `json:"daemon"`
+	// Template is where `template publish` uploads templates.
+	Template TemplateConfig `json:"template"`
+	// Hooks maps a command, such as `item complete`, or a command class, such as `complete`,
+	// to a shell command run after it succeeded.
+	Hooks map[string]string `json:"hooks,omitempty"`
+	// Metered limits the syncs on metered connections.
+	Metered MeteredConfig `json:"metered"`
+	// Bulk guards the commands which change many items at once.
+	Bulk BulkConfig `json:"bulk"`
+	// Storage places the local state in a database instead of files.
+	Storage StorageConfig `json:"storage"`
+	// ViewCache keeps the output of views, such as today, while nothing changed.
+	ViewCache ViewCacheConfig `json:"view_cache"`
+	// RateLimit is the budget of requests shared with the daemon.
+	RateLimit RateLimitConfig `json:"rate_limit"`
+	// Routes choose the project of `quick` items without a project. The first matching route wins.
+	Routes []Route `json:"routes,omitempty"`
+	// Preferences chosen in the setup. The flags of a command override them.
+	Timezone       string `json:"timezone,omitempty"`
+	DefaultProject string `json:"default_project,omitempty"`
+	// Color is auto, always or never.
+	Color  string `json:"color,omitempty"`
+	Output string `json:"output,omitempty"`
+}
+
+// ErrNoToken is returned when neither the environment, the config nor the keyring has a token.
+var ErrNoToken = errors.New("no todoist token is configured, run `todoist config` or set TODOIST_TOKEN")
+
+// DaemonConfig is the schedule of `todoist daemon`. Intervals are durations like `5m`.
+type DaemonConfig struct {
+	SyncInterval string       `json:"sync_interval"`
+	Backup       BackupConfig `json:"backup"`
+	// StaleInbox labels the inbox items which have not been triaged for days.
+	StaleInbox StaleInboxConfig `json:"stale_inbox"`
+}
+
+type BackupConfig struct {
+	Interval string `json:"interval"`
+	// Retention is the number of backups to keep.
+	Retention int `json:"retention"`
+}
+
+// MeteredConfig limits the bandwidth of syncs. The http client asks for gzip responses in any case.
+type MeteredConfig struct {
+	Enabled bool `json:"enabled"`
+	// MinInterval is the least duration between syncs of `todoist sync` and the daemon, such as `15m`.
+	MinInterval string `json:"min_interval,omitempty"`
+	// Exclude are the resource types not to sync, such as notes and collaborators.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Metered is the metered mode of this run, from the config and the --metered flag.
+var Metered MeteredConfig
+
+// Interval returns MinInterval, 15 minutes by default.
+func (m MeteredConfig) Interval() (time.Duration, error) {
+	if len(m.MinInterval) == 0 {
+		return 15 * time.Minute, nil
+	}
+	return time.ParseDuration(m.MinInterval)
+}
+
+// ResourceTypes returns the resource types to sync.
+func (m MeteredConfig) ResourceTypes() []string {
+	types := []string{"all"}
+	for _, t := range m.Exclude {
+		types = append(types, "-"+t)
+	}
+	return types
+}
+
+// syncedAt is the time of the last sync of the cache read by NewClient.
+var syncedAt time.Time
+
+// StaleNote returns a note like `stale by 12m` in metered mode, when the cache is older than a minute.
+func StaleNote() string {
+	if !Metered.Enabled || syncedAt.IsZero() {
+		return ""
+	}
+	d := time.Since(syncedAt)
+	if d < time.Minute {
+		return ""
+	}
+	return fmt.Sprintf("stale by %s", formatAge(d))
+}
+
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd", int(d.Hours())/24)
+}
+
+// TemplateConfig is the backend to share templates.
+type TemplateConfig struct {
+	// Backend is gist or paste.
+	Backend string `json:"backend"`
+	// URL is the endpoint of the paste backend, which answers the url of a posted text.
+	URL string `json:"url"`
+	// Token is the github token to create gists.
 	Token string `json:"token"`
 }
 
+// SMTPConfig is the mail server used to send digests.
+type SMTPConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+}
+
+// LoadConfig reads the config file written by `todoist config`.
+func LoadConfig() (*Config, error) {
+	file := filepath.Join(todoist.DefaultDir(), "config.json")
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// WriteConfig writes the config file through a temporary file, so that it is replaced at once.
+// The file keeps its mode, and a new one is readable by the owner only as it may hold the token.
+func WriteConfig(c *Config) error {
+	file := filepath.Join(todoist.DefaultDir(), "config.json")
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(0600)
+	if fi, err := os.Stat(file); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "config.json.")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), mode); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// ApplyPreferences sets the timezone, color and output format of the config.
+// The output format of the config is not used when the output flag is given.
+func (c Config) ApplyPreferences(outputChanged bool) error {
+	if len(c.Timezone) != 0 {
+		loc, err := time.LoadLocation(c.Timezone)
+		if err != nil {
+			return err
+		}
+		time.Local = loc
+	}
+	switch c.Color {
+	case "always":
+		color.NoColor = false
+	case "never":
+		color.NoColor = true
+	}
+	if len(c.Output) != 0 && !outputChanged {
+		OutputFormat = c.Output
+	}
+	// --metered turns the metered mode on regardless of the config
+	enabled := Metered.Enabled || c.Metered.Enabled
+	Metered = c.Metered
+	Metered.Enabled = enabled
+	Bulk = c.Bulk
+	Storage = c.Storage
+	ViewCache = c.ViewCache
+	RateLimit = c.RateLimit
+	return nil
+}
+
 func resolveToken() string {
 	if s := viper.GetString("TODOIST_TOKEN"); len(s) != 0 {
 		return s
 	}
-	file := os.ExpandEnv("$HOME/.go-todoist/config.json")
-	if b, err := ioutil.ReadFile(file); err != nil {
-		return ""
-	} else {
-		var c Config
-		if err = json.Unmarshal(b, &c); err != nil {
-			return ""
-		}
+	if c, err := LoadConfig(); err == nil && len(c.Token) != 0 {
 		return c.Token
 	}
+	s, _ := readKeyring()
+	return s
+}
+
+// Setup asks the token and preferences, set by the command line. NewClient starts it
+// on the first run instead of failing without a token.
+var Setup func() error
+
+// setupOnFirstRun starts Setup when neither a token nor a config is found and stdin is a terminal,
+// and returns the token it configured.
+func setupOnFirstRun() (string, error) {
+	_, err := os.Stat(filepath.Join(todoist.DefaultDir(), "config.json"))
+	if Setup == nil || !os.IsNotExist(err) || !IsTerminal(os.Stdin) {
+		return "", ErrNoToken
+	}
+	setup := Setup
+	Setup = nil
+	fmt.Println("no config found, starting the setup")
+	if err = setup(); err != nil {
+		return "", err
+	}
+	return resolveToken(), nil
 }
 
 func NewClient() (*todoist.Client, error) {
-	return todoist.NewClient(
-		"",
-		resolveToken(),
+	token := resolveToken()
+	StartupProfile.Mark("resolve token")
+	if len(token) == 0 {
+		var err error
+		if token, err = setupOnFirstRun(); err != nil {
+			return nil, err
+		}
+		if len(token) == 0 {
+			return nil, ErrNoToken
+		}
+	}
+	client, err := todoist.NewClient(
+		viper.GetString("TODOIST_ENDPOINT"),
+		token,
 		"*",
 		"",
-		nil)
+		StartupProfile.logger())
+	StartupProfile.Mark("new client")
+	if err != nil {
+		return nil, err
+	}
+	client.HTTPClient = budgetClient(token)
+	if client.Store, err = OpenStore(); err != nil {
+		return nil, err
+	}
+	if Metered.Enabled {
+		client.Incremental = true
+		client.ResourceTypes = Metered.ResourceTypes()
+	}
+	syncedAt = client.SyncedAt()
+	if OfferPendingOnce {
+		OfferPendingOnce = false
+		if err = OfferPending(client); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
 }
 
 func AutoCommit(f func(client todoist.Client, ctx context.Context) error) error {
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"github.com/fatih/color"
+	"golang.org/x/sys/windows"
+	"os"
+)
+
+// enable the escape sequences of colors on the console.
+// Consoles older than windows 10 do not support them, so colors are disabled there.
+func init() {
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		h := windows.Handle(f.Fd())
+		var mode uint32
+		if err := windows.GetConsoleMode(h, &mode); err != nil {
+			// not a console, e.g. redirected to a file
+			continue
+		}
+		if err := windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+			color.NoColor = true
+		}
+	}
+}
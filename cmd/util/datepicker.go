@@ -0,0 +1,190 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+	"strings"
+	"time"
+)
+
+const datePickerHelp = "←→ day ↑↓ week pgup/pgdn month, t today m tomorrow w next week, enter ok, n no date, q cancel"
+
+// PickDate asks a date on a calendar moved by the arrow keys, starting from initial or today.
+// It returns zero time for no date, and ErrAbort when canceled. Without a terminal,
+// it asks the date as a line instead.
+func PickDate(msg string, initial time.Time) (time.Time, error) {
+	today := truncateDay(time.Now())
+	if initial.IsZero() {
+		initial = today
+	}
+	if !IsTerminal(os.Stdin) || !IsTerminal(os.Stdout) {
+		return promptDate(msg)
+	}
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return promptDate(msg)
+	}
+	defer restore()
+
+	date := truncateDay(initial)
+	lines := 0
+	for {
+		if lines > 0 {
+			// redraw over the former calendar
+			fmt.Printf("\x1b[%dA\r\x1b[J", lines)
+		}
+		s := renderCalendar(msg, date, today)
+		lines = strings.Count(s, "\n")
+		fmt.Print(s)
+
+		key, err := readKey()
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch key {
+		case "left", "h":
+			date = date.AddDate(0, 0, -1)
+		case "right", "l":
+			date = date.AddDate(0, 0, 1)
+		case "up", "k":
+			date = date.AddDate(0, 0, -7)
+		case "down", "j":
+			date = date.AddDate(0, 0, 7)
+		case "pgup", "<":
+			date = date.AddDate(0, -1, 0)
+		case "pgdn", ">":
+			date = date.AddDate(0, 1, 0)
+		case "t":
+			date = today
+		case "m":
+			date = today.AddDate(0, 0, 1)
+		case "w":
+			date = nextMonday(today)
+		case "enter":
+			return date, nil
+		case "n":
+			return time.Time{}, nil
+		case "q", "esc", "ctrl-c":
+			return time.Time{}, ErrAbort
+		}
+	}
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func nextMonday(t time.Time) time.Time {
+	n := (8 - int(t.Weekday())) % 7
+	if n == 0 {
+		n = 7
+	}
+	return t.AddDate(0, 0, n)
+}
+
+// renderCalendar draws the month of date from monday, marking date and today.
+func renderCalendar(msg string, date, today time.Time) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s: %s\n", msg, date.Format("Mon, Jan 2 2006"))
+	fmt.Fprintf(&buf, "      %s\n", date.Format("January 2006"))
+	buf.WriteString(" Mo Tu We Th Fr Sa Su\n")
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local)
+	// monday is the first column
+	offset := (int(first.Weekday()) + 6) % 7
+	buf.WriteString(strings.Repeat("   ", offset))
+	for d := first; d.Month() == date.Month(); d = d.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%3d", d.Day())
+		switch {
+		case d.Equal(date):
+			cell = "\x1b[7m" + cell + "\x1b[0m"
+		case d.Equal(today):
+			cell = "\x1b[4m" + cell + "\x1b[0m"
+		}
+		buf.WriteString(cell)
+		if d.Weekday() == time.Sunday {
+			buf.WriteString("\n")
+		}
+	}
+	if !strings.HasSuffix(buf.String(), "\n") {
+		buf.WriteString("\n")
+	}
+	buf.WriteString(datePickerHelp + "\n")
+	return buf.String()
+}
+
+// readKey reads a key in the raw mode and names the special keys.
+func readKey() (string, error) {
+	b, err := stdin.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	switch b {
+	case '\r', '\n':
+		return "enter", nil
+	case 3:
+		return "ctrl-c", nil
+	case 0x1b:
+		// a lone escape has nothing buffered after it
+		if stdin.Buffered() == 0 {
+			return "esc", nil
+		}
+		seq := []byte{}
+		for stdin.Buffered() > 0 && len(seq) < 4 {
+			c, _ := stdin.ReadByte()
+			seq = append(seq, c)
+			if c >= 'A' && c <= 'Z' || c == '~' {
+				break
+			}
+		}
+		switch string(seq) {
+		case "[A", "OA":
+			return "up", nil
+		case "[B", "OB":
+			return "down", nil
+		case "[C", "OC":
+			return "right", nil
+		case "[D", "OD":
+			return "left", nil
+		case "[5~":
+			return "pgup", nil
+		case "[6~":
+			return "pgdn", nil
+		}
+		return "", nil
+	}
+	return string(b), nil
+}
+
+func promptDate(msg string) (time.Time, error) {
+	for {
+		ans, ok := Prompt(fmt.Sprintf("%s (YYYY-MM-DD, t: today, m: tomorrow, w: next week, empty: no date)", msg))
+		if !ok {
+			return time.Time{}, ErrAbort
+		}
+		today := truncateDay(time.Now())
+		switch ans = strings.TrimSpace(ans); ans {
+		case "":
+			return time.Time{}, nil
+		case "t":
+			return today, nil
+		case "m":
+			return today.AddDate(0, 0, 1), nil
+		case "w":
+			return nextMonday(today), nil
+		}
+		if t, err := time.ParseInLocation("2006-01-02", ans, time.Local); err == nil {
+			return t, nil
+		}
+		fmt.Printf("invalid date: %s\n", ans)
+	}
+}
+
+// SetDueDate sets the due of the item to the date without time. A recurring item keeps its recurrence.
+func SetDueDate(item *todoist.Item, date time.Time) {
+	item.Due.Date = todoist.Time{Time: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)}
+	if !item.Due.IsRecurring {
+		item.Due.String = date.Format("2006-01-02")
+	}
+}
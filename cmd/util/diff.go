@@ -0,0 +1,169 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"strconv"
+	"strings"
+)
+
+// ItemDiffFields are the fields which ItemDiffString compares by default.
+var ItemDiffFields = []string{"content", "due", "labels", "priority", "project", "section", "parent"}
+
+// CheckItemDiffFields returns an error for a field which ItemDiffString does not know.
+func CheckItemDiffFields(fields []string) error {
+	for _, field := range fields {
+		if _, err := itemFieldValue(todoist.Item{}, strings.TrimSpace(field), todoist.ItemRelations{}); err != nil {
+			return fmt.Errorf("%s, choose from %s", err, strings.Join(ItemDiffFields, ", "))
+		}
+	}
+	return nil
+}
+
+func itemFieldValue(item todoist.Item, field string, relations todoist.ItemRelations) (string, error) {
+	switch field {
+	case "content":
+		return item.Content, nil
+	case "due":
+		if len(item.Due.String) != 0 {
+			return fmt.Sprintf("%s (%s)", item.Due.Date, item.Due.String), nil
+		}
+		return item.Due.Date.String(), nil
+	case "labels":
+		var labels todoist.Labels
+		for _, lid := range item.Labels {
+			if v, ok := relations.Labels[lid]; ok {
+				labels = append(labels, v)
+			}
+		}
+		return labels.String(), nil
+	case "priority":
+		return strconv.Itoa(item.Priority), nil
+	case "project":
+		if p, ok := relations.Projects[item.ProjectID]; ok {
+			return p.String(), nil
+		}
+		return item.ProjectID.String(), nil
+	case "section":
+		if s, ok := relations.Sections[item.SectionID]; ok {
+			return s.String(), nil
+		}
+		return "", nil
+	case "parent":
+		if item.ParentID.IsZero() {
+			return "", nil
+		}
+		return item.ParentID.String(), nil
+	}
+	return "", fmt.Errorf("unknown field: %s", field)
+}
+
+// ItemDiffString shows the changed fields of an item as `field before -> after` rows.
+func ItemDiffString(before, after todoist.Item, relations todoist.ItemRelations, fields []string) (string, error) {
+	if len(fields) == 0 {
+		fields = ItemDiffFields
+	}
+	var rows [][]todoist.ColorStringer
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		b, err := itemFieldValue(before, field, relations)
+		if err != nil {
+			return "", err
+		}
+		a, err := itemFieldValue(after, field, relations)
+		if err != nil {
+			return "", err
+		}
+		if a == b {
+			continue
+		}
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(field),
+			todoist.NewNoColorString(b),
+			todoist.NewNoColorString("->"),
+			todoist.NewNoColorString(a),
+		})
+	}
+	if len(rows) == 0 {
+		return "no changes", nil
+	}
+	return TableString(rows), nil
+}
+
+// ChangesOutput has a row for each changed resource, marked by +, - or ~ for added, removed and modified.
+func ChangesOutput(c todoist.Changes) Output {
+	var rows [][]todoist.ColorStringer
+	add := func(mark, kind string, id todoist.ID, name string) {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(mark),
+			todoist.NewNoColorString(kind),
+			todoist.NewNoColorString(id.String()),
+			todoist.NewNoColorString(name),
+		})
+	}
+	for _, p := range c.Projects.Added {
+		add("+", "project", p.ID, p.Name)
+	}
+	for _, p := range c.Projects.Removed {
+		add("-", "project", p.ID, p.Name)
+	}
+	for _, p := range c.Projects.Modified {
+		add("~", "project", p.After.ID, p.After.Name)
+	}
+	for _, s := range c.Sections.Added {
+		add("+", "section", s.ID, s.Name)
+	}
+	for _, s := range c.Sections.Removed {
+		add("-", "section", s.ID, s.Name)
+	}
+	for _, s := range c.Sections.Modified {
+		add("~", "section", s.After.ID, s.After.Name)
+	}
+	for _, i := range c.Items.Added {
+		add("+", "item", i.ID, i.Content)
+	}
+	for _, i := range c.Items.Removed {
+		add("-", "item", i.ID, i.Content)
+	}
+	for _, i := range c.Items.Modified {
+		add("~", "item", i.After.ID, i.After.Content)
+	}
+	for _, notes := range []struct {
+		kind string
+		todoist.NoteChanges
+	}{{"note", c.Notes}, {"project note", c.ProjectNotes}} {
+		kind := notes.kind
+		for _, n := range notes.Added {
+			add("+", kind, n.ID, n.Content)
+		}
+		for _, n := range notes.Removed {
+			add("-", kind, n.ID, n.Content)
+		}
+		for _, n := range notes.Modified {
+			add("~", kind, n.After.ID, n.After.Content)
+		}
+	}
+	for _, l := range c.Labels.Added {
+		add("+", "label", l.ID, l.Name)
+	}
+	for _, l := range c.Labels.Removed {
+		add("-", "label", l.ID, l.Name)
+	}
+	for _, l := range c.Labels.Modified {
+		add("~", "label", l.After.ID, l.After.Name)
+	}
+	for _, f := range c.Filters.Added {
+		add("+", "filter", f.ID, f.Name)
+	}
+	for _, f := range c.Filters.Removed {
+		add("-", "filter", f.ID, f.Name)
+	}
+	for _, f := range c.Filters.Modified {
+		add("~", "filter", f.After.ID, f.After.Name)
+	}
+	return Output{
+		Columns: []string{"change", "type", "id", "name"},
+		Rows:    rows,
+		Data:    c,
+	}
+}
@@ -0,0 +1,196 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	htmltemplate "html/template"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Digest is the weekly review report rendered into an email.
+type Digest struct {
+	Since     time.Time
+	Until     time.Time
+	Completed []DigestDay
+	Overdue   []DigestItem
+	Upcoming  []DigestItem
+}
+
+type DigestDay struct {
+	Date  string
+	Items []DigestItem
+}
+
+type DigestItem struct {
+	Content string
+	Project string
+	Date    string
+}
+
+func (d Digest) CompletedCount() int {
+	n := 0
+	for _, day := range d.Completed {
+		n += len(day.Items)
+	}
+	return n
+}
+
+func (d Digest) Subject() string {
+	return fmt.Sprintf("todoist weekly review: %s - %s", d.Since.Format("2006-01-02"), d.Until.Format("2006-01-02"))
+}
+
+func NewDigest(since, until time.Time, completed *todoist.CompletedItems, overdue, upcoming []todoist.Item, relations todoist.ItemRelations) Digest {
+	d := Digest{Since: since, Until: until}
+	days := completed.GroupByCompletedDate()
+	var keys []string
+	for k := range days {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		day := DigestDay{Date: k}
+		for _, i := range days[k] {
+			day.Items = append(day.Items, DigestItem{
+				Content: i.Content,
+				Project: completed.Projects[i.ProjectID].String(),
+				Date:    i.CompletedDate.String(),
+			})
+		}
+		d.Completed = append(d.Completed, day)
+	}
+	newItems := func(items []todoist.Item) []DigestItem {
+		var res []DigestItem
+		for _, i := range items {
+			res = append(res, DigestItem{
+				Content: i.Content,
+				Project: relations.Projects[i.ProjectID].String(),
+				Date:    i.Due.Date.String(),
+			})
+		}
+		return res
+	}
+	d.Overdue = newItems(overdue)
+	d.Upcoming = newItems(upcoming)
+	return d
+}
+
+const digestText = `{{.Subject}}
+
+Completed ({{.CompletedCount}})
+{{range .Completed}}  {{.Date}}
+{{range .Items}}    - {{.Content}} ({{.Project}})
+{{end}}{{end}}
+Overdue ({{len .Overdue}})
+{{range .Overdue}}  - {{.Content}} ({{.Project}}) due {{.Date}}
+{{end}}
+Upcoming ({{len .Upcoming}})
+{{range .Upcoming}}  - {{.Content}} ({{.Project}}) due {{.Date}}
+{{end}}`
+
+const digestHTML = `<html><body>
+<h1>{{.Subject}}</h1>
+<h2>Completed ({{.CompletedCount}})</h2>
+{{range .Completed}}<h3>{{.Date}}</h3>
+<ul>{{range .Items}}<li>{{.Content}} <small>{{.Project}}</small></li>{{end}}</ul>
+{{end}}<h2>Overdue ({{len .Overdue}})</h2>
+<ul>{{range .Overdue}}<li>{{.Content}} <small>{{.Project}} due {{.Date}}</small></li>{{end}}</ul>
+<h2>Upcoming ({{len .Upcoming}})</h2>
+<ul>{{range .Upcoming}}<li>{{.Content}} <small>{{.Project}} due {{.Date}}</small></li>{{end}}</ul>
+</body></html>
+`
+
+func (d Digest) Text() (string, error) {
+	var buf bytes.Buffer
+	if err := template.Must(template.New("digest").Parse(digestText)).Execute(&buf, d); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (d Digest) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := htmltemplate.Must(htmltemplate.New("digest").Parse(digestHTML)).Execute(&buf, d); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Message builds a multipart/alternative email carrying both renderings.
+func (d Digest) Message(from string, to []string) ([]byte, error) {
+	text, err := d.Text()
+	if err != nil {
+		return nil, err
+	}
+	html, err := d.HTML()
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err = qw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err = qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", d.Subject())
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", w.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// SendMail delivers msg through the configured SMTP server.
+// addr overrides the host and port of the config when it is not empty.
+func SendMail(c SMTPConfig, addr string, to []string, msg []byte) error {
+	if len(addr) == 0 {
+		if len(c.Host) == 0 {
+			return errors.New("require smtp host")
+		}
+		port := c.Port
+		if port == 0 {
+			port = 587
+		}
+		addr = net.JoinHostPort(c.Host, strconv.Itoa(port))
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if len(c.Username) != 0 {
+		auth = smtp.PlainAuth("", c.Username, c.Password, host)
+	}
+	return smtp.SendMail(addr, auth, c.From, to, msg)
+}
@@ -0,0 +1,83 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+	"time"
+)
+
+// Focus is a filter query which narrows the lists of items until it is cleared or expires.
+type Focus struct {
+	Query string    `json:"query"`
+	Since time.Time `json:"since"`
+	// Until is the expiry of the focus, zero for none.
+	Until time.Time `json:"until,omitempty"`
+}
+
+const focusState = "focus"
+
+// NoFocus ignores the focus in this run.
+var NoFocus bool
+
+// applied is the focus which narrowed the items of this run.
+var applied *Focus
+
+// SetFocus stores the query as the focus, which expires after d unless d is zero.
+func SetFocus(query string, d time.Duration) (*Focus, error) {
+	if _, err := todoist.ParseQuery(query); err != nil {
+		return nil, err
+	}
+	f := Focus{Query: query, Since: time.Now()}
+	if d > 0 {
+		f.Until = f.Since.Add(d)
+	}
+	if err := WriteState(focusState, f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// LoadFocus returns the current focus, or nil when no focus is set or it has expired.
+func LoadFocus() (*Focus, error) {
+	var f Focus
+	if err := ReadState(focusState, &f); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(f.Query) == 0 || (!f.Until.IsZero() && time.Now().After(f.Until)) {
+		return nil, nil
+	}
+	return &f, nil
+}
+
+// ClearFocus removes the focus.
+func ClearFocus() error {
+	return DeleteState(focusState)
+}
+
+// ApplyFocus returns the items matching the focus, or all items without a focus or with --no-focus.
+func ApplyFocus(client *todoist.Client, items []todoist.Item) ([]todoist.Item, error) {
+	if NoFocus {
+		return items, nil
+	}
+	f, err := LoadFocus()
+	if err != nil || f == nil {
+		return items, err
+	}
+	applied = f
+	return client.Item.FilterByQuery(items, f.Query)
+}
+
+// FocusNote returns a note like `focus: #Deep & today` when the focus narrowed the items.
+func FocusNote() string {
+	if applied == nil {
+		return ""
+	}
+	if applied.Until.IsZero() {
+		return fmt.Sprintf("focus: %s", applied.Query)
+	}
+	return fmt.Sprintf("focus: %s, %s left", applied.Query, formatAge(time.Until(applied.Until)))
+}
@@ -56,6 +56,10 @@ func TableString(rows [][]todoist.ColorStringer) string {
 }
 
 func FilterTableString(filters []todoist.Filter) string {
+	return TableString(filterRows(filters))
+}
+
+func filterRows(filters []todoist.Filter) [][]todoist.ColorStringer {
 	sort.Slice(filters, func(i, j int) bool {
 		return filters[i].ItemOrder < filters[j].ItemOrder
 	})
@@ -67,10 +71,10 @@ func FilterTableString(filters []todoist.Filter) string {
 			todoist.NewNoColorString(f.Query),
 		})
 	}
-	return TableString(rows)
+	return rows
 }
 
-func ItemTableString(items []todoist.Item, relations todoist.ItemRelations, f func(item todoist.Item) todoist.Time) string {
+func itemRows(items []todoist.Item, relations todoist.ItemRelations, f func(item todoist.Item) todoist.Time) [][]todoist.ColorStringer {
 	var rows [][]todoist.ColorStringer
 	for _, i := range items {
 		project := todoist.Project{}
@@ -92,10 +96,18 @@ func ItemTableString(items []todoist.Item, relations todoist.ItemRelations, f fu
 			todoist.NewNoColorString(i.Content),
 		})
 	}
-	return TableString(rows)
+	return rows
+}
+
+func ItemTableString(items []todoist.Item, relations todoist.ItemRelations, f func(item todoist.Item) todoist.Time) string {
+	return TableString(itemRows(items, relations, f))
 }
 
 func ProjectTableString(projects []todoist.Project) string {
+	return TableString(projectRows(projects))
+}
+
+func projectRows(projects []todoist.Project) [][]todoist.ColorStringer {
 	var rows [][]todoist.ColorStringer
 	indentMaps := map[string]int{}
 	for _, p := range projects {
@@ -111,10 +123,14 @@ func ProjectTableString(projects []todoist.Project) string {
 			todoist.NewNoColorString(strings.Repeat(" ", indent) + p.ColorString()),
 		})
 	}
-	return TableString(rows)
+	return rows
 }
 
 func LabelTableString(labels []todoist.Label) string {
+	return TableString(labelRows(labels))
+}
+
+func labelRows(labels []todoist.Label) [][]todoist.ColorStringer {
 	sort.Slice(labels, func(i, j int) bool {
 		return labels[i].ItemOrder < labels[j].ItemOrder
 	})
@@ -125,5 +141,39 @@ func LabelTableString(labels []todoist.Label) string {
 			l,
 		})
 	}
+	return rows
+}
+
+func NoteTableString(notes []todoist.Note) string {
+	return TableString(noteRows(notes))
+}
+
+func noteRows(notes []todoist.Note) [][]todoist.ColorStringer {
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].Posted.Before(notes[j].Posted)
+	})
+	var rows [][]todoist.ColorStringer
+	for _, n := range notes {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(n.ID.String()),
+			n.Posted,
+			todoist.NewNoColorString(n.Content),
+		})
+	}
+	return rows
+}
+
+func SectionTableString(sections []todoist.Section, counts map[todoist.ID]int) string {
+	sort.Slice(sections, func(i, j int) bool {
+		return sections[i].SectionOrder < sections[j].SectionOrder
+	})
+	var rows [][]todoist.ColorStringer
+	for _, s := range sections {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(s.ID.String()),
+			s,
+			todoist.NewNoColorString(strconv.Itoa(counts[s.ID])),
+		})
+	}
 	return TableString(rows)
 }
@@ -0,0 +1,83 @@
+package util
+
+import (
+	"github.com/kobtea/go-todoist/todoist"
+)
+
+// GeneratedOperations returns the operations adding the projects, sections, labels and items of a generated state
+// to an account, with the ids of the state replaced by temp ids. The inbox of the state is the inbox of the account.
+func GeneratedOperations(state todoist.SyncState, inbox todoist.ID) []todoist.Operation {
+	ids := map[todoist.ID]todoist.ID{}
+	resolve := func(id todoist.ID) todoist.ID {
+		if id.IsZero() {
+			return id
+		}
+		return ids[id]
+	}
+	var ops []todoist.Operation
+	for _, p := range state.Projects {
+		if p.InboxProject {
+			ids[p.ID] = inbox
+			continue
+		}
+		p := p
+		ops = append(ops, func(c *todoist.Client) error {
+			id := p.ID
+			p.ID = todoist.GenerateTempID()
+			p.ParentID = resolve(p.ParentID)
+			ids[id] = p.ID
+			_, err := c.Project.Add(p)
+			return err
+		})
+	}
+	for _, s := range state.Sections {
+		s := s
+		ops = append(ops, func(c *todoist.Client) error {
+			section, err := todoist.NewSection(s.Name, resolve(s.ProjectID))
+			if err != nil {
+				return err
+			}
+			section.SectionOrder = s.SectionOrder
+			ids[s.ID] = section.ID
+			_, err = c.Section.Add(*section)
+			return err
+		})
+	}
+	for _, l := range state.Labels {
+		l := l
+		ops = append(ops, func(c *todoist.Client) error {
+			label, err := todoist.NewLabel(l.Name, &todoist.NewLabelOpts{Color: l.Color, ItemOrder: l.ItemOrder})
+			if err != nil {
+				return err
+			}
+			ids[l.ID] = label.ID
+			_, err = c.Label.Add(*label)
+			return err
+		})
+	}
+	// parents come before their sub items in a generated state
+	for _, i := range state.Items {
+		i := i
+		ops = append(ops, func(c *todoist.Client) error {
+			item := todoist.Item{
+				ProjectID:  resolve(i.ProjectID),
+				SectionID:  resolve(i.SectionID),
+				ParentID:   resolve(i.ParentID),
+				Content:    i.Content,
+				Due:        i.Due,
+				Priority:   i.Priority,
+				ChildOrder: i.ChildOrder,
+			}
+			for _, l := range i.Labels {
+				item.Labels = append(item.Labels, resolve(l))
+			}
+			added, err := c.Item.Add(item)
+			if err != nil {
+				return err
+			}
+			ids[i.ID] = added.ID
+			return nil
+		})
+	}
+	return ops
+}
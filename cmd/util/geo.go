@@ -0,0 +1,151 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const geoPrefix = "geo:"
+
+// Location is the point of an item, kept in a note of the item as a geo uri
+// followed by an optional name, e.g. `geo:35.6812,139.7671 Tokyo Station`.
+type Location struct {
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+	Name string  `json:"name,omitempty"`
+}
+
+func ParseLocation(s string) (*Location, error) {
+	if !strings.HasPrefix(s, geoPrefix) {
+		return nil, fmt.Errorf("not a geo uri: %s", s)
+	}
+	s = strings.TrimPrefix(s, geoPrefix)
+	var name string
+	if i := strings.Index(s, " "); i >= 0 {
+		s, name = s[:i], strings.TrimSpace(s[i+1:])
+	}
+	// parameters such as ;u=35 are ignored
+	if i := strings.Index(s, ";"); i >= 0 {
+		s = s[:i]
+	}
+	coords := strings.Split(s, ",")
+	if len(coords) < 2 {
+		return nil, fmt.Errorf("invalid geo uri: %s", s)
+	}
+	lat, err := strconv.ParseFloat(coords[0], 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("invalid latitude: %s", coords[0])
+	}
+	lon, err := strconv.ParseFloat(coords[1], 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return nil, fmt.Errorf("invalid longitude: %s", coords[1])
+	}
+	return &Location{Lat: lat, Lon: lon, Name: name}, nil
+}
+
+func (l Location) String() string {
+	s := geoPrefix + strconv.FormatFloat(l.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(l.Lon, 'f', -1, 64)
+	if len(l.Name) != 0 {
+		s += " " + l.Name
+	}
+	return s
+}
+
+// Distance returns the great-circle distance to the location in meters.
+func (l Location) Distance(to Location) float64 {
+	const earthRadius = 6371000
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(to.Lat - l.Lat)
+	dLon := rad(to.Lon - l.Lon)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rad(l.Lat))*math.Cos(rad(to.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// ParseDistance parses a distance such as 2km, 500m or 1.5mi into meters.
+// A number without a unit is in meters.
+func ParseDistance(s string) (float64, error) {
+	orig, unit := s, 1.0
+	for _, u := range []struct {
+		suffix string
+		meters float64
+	}{{"km", 1000}, {"mi", 1609.344}, {"m", 1}} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, unit = strings.TrimSuffix(s, u.suffix), u.meters
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid distance: %s", orig)
+	}
+	return n * unit, nil
+}
+
+func FormatDistance(meters float64) string {
+	if meters < 1000 {
+		return fmt.Sprintf("%.0fm", meters)
+	}
+	return fmt.Sprintf("%.1fkm", meters/1000)
+}
+
+// FindLocation returns the location of the item and its note, preferring the latest note.
+func FindLocation(notes []todoist.Note) (*Location, *todoist.Note) {
+	for i := len(notes) - 1; i >= 0; i-- {
+		if l, err := ParseLocation(notes[i].Content); err == nil {
+			return l, &notes[i]
+		}
+	}
+	return nil, nil
+}
+
+// NearbyItem is an item with its location and the distance from the point of `todoist nearby`.
+type NearbyItem struct {
+	todoist.Item
+	Location Location `json:"location"`
+	Distance float64  `json:"distance"`
+}
+
+// NearbyItems returns the unchecked items located within radius meters of the point, the nearest first.
+func NearbyItems(client *todoist.Client, point Location, radius float64) []NearbyItem {
+	var res []NearbyItem
+	for _, i := range client.Item.GetAll() {
+		if i.IsChecked() {
+			continue
+		}
+		l, _ := FindLocation(client.Note.GetAllForItem(i.ID))
+		if l == nil {
+			continue
+		}
+		if d := point.Distance(*l); d <= radius {
+			res = append(res, NearbyItem{Item: i, Location: *l, Distance: d})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Distance < res[j].Distance
+	})
+	return res
+}
+
+func NearbyOutput(items []NearbyItem, relations todoist.ItemRelations) Output {
+	if items == nil {
+		items = []NearbyItem{}
+	}
+	var plain []todoist.Item
+	for _, i := range items {
+		plain = append(plain, i.Item)
+	}
+	o := ItemsOutput(plain, relations, func(i todoist.Item) todoist.Time { return i.Due.Date })
+	o.Columns = append([]string{"distance", "place"}, o.Columns...)
+	for n, row := range o.Rows {
+		o.Rows[n] = append([]todoist.ColorStringer{
+			todoist.NewNoColorString(FormatDistance(items[n].Distance)),
+			todoist.NewNoColorString(items[n].Location.Name),
+		}, row...)
+	}
+	o.Data = items
+	return o
+}
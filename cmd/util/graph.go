@@ -0,0 +1,129 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"sort"
+	"strings"
+)
+
+// Graph is the structure of a project: sub projects, sections and items as nodes,
+// containment and parent/child as solid edges, and item links as dependency edges.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+type GraphNode struct {
+	ID    string
+	Label string
+	Kind  string
+}
+
+type GraphEdge struct {
+	From, To   string
+	Dependency bool
+}
+
+func NewGraph(client *todoist.Client, projectID todoist.ID) (*Graph, error) {
+	root := client.Project.Resolve(projectID)
+	if root == nil {
+		return nil, fmt.Errorf("invalid project id: %s", projectID)
+	}
+	g := &Graph{}
+	projects := []todoist.Project{*root}
+	for i := 0; i < len(projects); i++ {
+		p := projects[i]
+		g.Nodes = append(g.Nodes, GraphNode{graphID("p", p.ID), p.Name, "project"})
+		if i > 0 {
+			g.Edges = append(g.Edges, GraphEdge{From: graphID("p", p.ParentID), To: graphID("p", p.ID)})
+		}
+		for _, c := range client.Project.GetAll() {
+			if c.ParentID == p.ID {
+				projects = append(projects, c)
+			}
+		}
+	}
+	ids := map[todoist.ID]bool{}
+	for _, p := range projects {
+		ids[p.ID] = true
+		sections := client.Section.FindByProjectID(p.ID)
+		sort.Slice(sections, func(i, j int) bool { return sections[i].SectionOrder < sections[j].SectionOrder })
+		for _, s := range sections {
+			g.Nodes = append(g.Nodes, GraphNode{graphID("s", s.ID), s.Name, "section"})
+			g.Edges = append(g.Edges, GraphEdge{From: graphID("p", p.ID), To: graphID("s", s.ID)})
+		}
+	}
+	var items []todoist.Item
+	inGraph := map[todoist.ID]bool{}
+	for _, i := range client.Item.GetAll() {
+		if ids[i.ProjectID] && !i.IsChecked() {
+			items = append(items, i)
+			inGraph[i.ID] = true
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ChildOrder < items[j].ChildOrder })
+	for _, i := range items {
+		g.Nodes = append(g.Nodes, GraphNode{graphID("i", i.ID), i.Content, "item"})
+		parent := graphID("p", i.ProjectID)
+		if inGraph[i.ParentID] {
+			parent = graphID("i", i.ParentID)
+		} else if !i.SectionID.IsZero() {
+			parent = graphID("s", i.SectionID)
+		}
+		g.Edges = append(g.Edges, GraphEdge{From: parent, To: graphID("i", i.ID)})
+		// an item depends on the items it references, e.g. [[123]] or https://todoist.com/showTask?id=123
+		for _, dep := range client.Item.References(i.ID) {
+			if inGraph[dep] {
+				g.Edges = append(g.Edges, GraphEdge{From: graphID("i", dep), To: graphID("i", i.ID), Dependency: true})
+			}
+		}
+	}
+	return g, nil
+}
+
+func graphID(prefix string, id todoist.ID) string {
+	return prefix + strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, id.String())
+}
+
+func (g Graph) DotString() string {
+	shapes := map[string]string{"project": "folder", "section": "tab", "item": "box"}
+	var buf bytes.Buffer
+	buf.WriteString("digraph todoist {\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&buf, "  %s [label=%q, shape=%s];\n", n.ID, n.Label, shapes[n.Kind])
+	}
+	for _, e := range g.Edges {
+		if e.Dependency {
+			fmt.Fprintf(&buf, "  %s -> %s [style=dashed, label=\"blocks\"];\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&buf, "  %s -> %s;\n", e.From, e.To)
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+func (g Graph) MermaidString() string {
+	shapes := map[string][2]string{"project": {"[[", "]]"}, "section": {"[/", "/]"}, "item": {"[", "]"}}
+	var buf bytes.Buffer
+	buf.WriteString("graph TD\n")
+	for _, n := range g.Nodes {
+		label := strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(n.Label)
+		fmt.Fprintf(&buf, "  %s%s\"%s\"%s\n", n.ID, shapes[n.Kind][0], label, shapes[n.Kind][1])
+	}
+	for _, e := range g.Edges {
+		if e.Dependency {
+			fmt.Fprintf(&buf, "  %s -. blocks .-> %s\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&buf, "  %s --> %s\n", e.From, e.To)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// RunHook runs the hook configured for the command path (e.g. `item complete`),
+// falling back to the hook of its class, which is the last word of the path (e.g. `complete`).
+// The hook gets the command and its arguments through TODOIST_COMMAND and TODOIST_ARGS.
+func RunHook(commandPath string, args []string) error {
+	c, err := LoadConfig()
+	if err != nil || len(c.Hooks) == 0 {
+		return nil
+	}
+	words := strings.Fields(commandPath)
+	if len(words) == 0 {
+		return nil
+	}
+	hook, ok := c.Hooks[commandPath]
+	if !ok {
+		if hook, ok = c.Hooks[words[len(words)-1]]; !ok {
+			return nil
+		}
+	}
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.Command(shell, flag, hook)
+	cmd.Env = append(os.Environ(),
+		"TODOIST_COMMAND="+commandPath,
+		"TODOIST_ARGS="+strings.Join(args, " "))
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,198 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StaleInboxConfig is the rule of the daemon which labels the inbox items not triaged for days.
+type StaleInboxConfig struct {
+	// Days is the age of a stale item. Zero disables the rule.
+	Days int `json:"days"`
+	// Label is `stale` by default, and is added when missing.
+	Label string `json:"label,omitempty"`
+}
+
+// LabelName returns the label of stale items.
+func (c StaleInboxConfig) LabelName() string {
+	if len(c.Label) == 0 {
+		return "stale"
+	}
+	return strings.TrimPrefix(c.Label, "@")
+}
+
+// FindInbox returns the inbox project of the account.
+func FindInbox(client *todoist.Client) (*todoist.Project, error) {
+	for _, p := range client.Project.GetAll() {
+		if p.InboxProject {
+			return &p, nil
+		}
+	}
+	projects := client.Project.FindByName("Inbox")
+	if len(projects) != 1 {
+		return nil, errors.New("Failed to detect inbox. It may exist multiple inbox.")
+	}
+	return &projects[0], nil
+}
+
+// ageDays returns the number of whole days since the item was added.
+func ageDays(i todoist.Item, now time.Time) int {
+	return int(now.Sub(i.DateAdded.Time).Hours() / 24)
+}
+
+// StaleItems returns the unchecked items added days ago or earlier.
+func StaleItems(items []todoist.Item, days int, now time.Time) []todoist.Item {
+	var res []todoist.Item
+	for _, i := range items {
+		if !i.IsChecked() && !i.DateAdded.IsZero() && ageDays(i, now) >= days {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// LabelStaleInbox adds the label of the rule to the stale inbox items and commits them.
+// It returns the items which were labelled.
+func LabelStaleInbox(ctx context.Context, client *todoist.Client, c StaleInboxConfig, now time.Time) ([]todoist.Item, error) {
+	inbox, err := FindInbox(client)
+	if err != nil {
+		return nil, err
+	}
+	var label *todoist.Label
+	for _, l := range client.Label.GetAll() {
+		if strings.EqualFold(l.Name, c.LabelName()) {
+			label = &l
+			break
+		}
+	}
+	var items []todoist.Item
+	for _, i := range StaleItems(client.Item.FindByProjectIDs([]todoist.ID{inbox.ID}), c.Days, now) {
+		if label != nil && HasLabel(i, label.ID) {
+			continue
+		}
+		items = append(items, i)
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	if label == nil {
+		if label, err = todoist.NewLabel(c.LabelName(), &todoist.NewLabelOpts{}); err != nil {
+			return nil, err
+		}
+		if _, err = client.Label.Add(*label); err != nil {
+			return nil, err
+		}
+	}
+	for _, i := range items {
+		if err = client.Item.UpdateLabels(i.ID, append(i.Labels, label.ID)); err != nil {
+			return nil, err
+		}
+	}
+	if err = client.Commit(ctx); err != nil {
+		return nil, err
+	}
+	if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// HasLabel reports whether the item has the label.
+func HasLabel(item todoist.Item, id todoist.ID) bool {
+	for _, l := range item.Labels {
+		if l == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AgeBucket counts the items whose age is below Max days, and at least the Max of the previous bucket.
+type AgeBucket struct {
+	Name  string `json:"name"`
+	Max   int    `json:"max_days,omitempty"`
+	Count int    `json:"count"`
+}
+
+// InboxPressure is the age distribution of the unchecked inbox items.
+type InboxPressure struct {
+	Total int `json:"total"`
+	// Stale is the number of items counted by StaleItems with the days of the stale rule.
+	Stale      int         `json:"stale"`
+	OldestDays int         `json:"oldest_days"`
+	Buckets    []AgeBucket `json:"buckets"`
+}
+
+// NewInboxPressure counts the unchecked items by age. Items without an added date
+// are counted in the total only.
+func NewInboxPressure(items []todoist.Item, staleDays int, now time.Time) InboxPressure {
+	p := InboxPressure{Buckets: []AgeBucket{
+		{Name: "< 1d", Max: 1},
+		{Name: "1-3d", Max: 3},
+		{Name: "3-7d", Max: 7},
+		{Name: "7-14d", Max: 14},
+		{Name: "14-30d", Max: 30},
+		{Name: "30d+"},
+	}}
+	for _, i := range items {
+		if i.IsChecked() {
+			continue
+		}
+		p.Total++
+		if i.DateAdded.IsZero() {
+			continue
+		}
+		days := ageDays(i, now)
+		if days > p.OldestDays {
+			p.OldestDays = days
+		}
+		if staleDays > 0 && days >= staleDays {
+			p.Stale++
+		}
+		for n := range p.Buckets {
+			if b := &p.Buckets[n]; b.Max == 0 || days < b.Max {
+				b.Count++
+				break
+			}
+		}
+	}
+	return p
+}
+
+// String returns a summary like `12 items, the oldest is 40 days old, 5 are stale`.
+func (p InboxPressure) String() string {
+	s := fmt.Sprintf("%d items, the oldest is %d days old", p.Total, p.OldestDays)
+	if p.Stale > 0 {
+		s += fmt.Sprintf(", %d are stale", p.Stale)
+	}
+	return s
+}
+
+// InboxPressureOutput has a row with a bar for each age bucket.
+func InboxPressureOutput(p InboxPressure) Output {
+	const width = 40
+	max := width
+	for _, b := range p.Buckets {
+		if b.Count > max {
+			max = b.Count
+		}
+	}
+	var rows [][]todoist.ColorStringer
+	for _, b := range p.Buckets {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(b.Name),
+			todoist.NewNoColorString(strconv.Itoa(b.Count)),
+			todoist.NewNoColorString(strings.Repeat("#", b.Count*width/max)),
+		})
+	}
+	return Output{
+		Columns: []string{"age", "items", "bar"},
+		Rows:    rows,
+		Data:    p,
+	}
+}
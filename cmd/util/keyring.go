@@ -0,0 +1,17 @@
+package util
+
+import "errors"
+
+// ErrKeyringUnsupported is returned where the token is kept in the config file instead of a keyring.
+var ErrKeyringUnsupported = errors.New("keyring is not supported on this platform")
+
+// StoreTokenInKeyring stores the token to the keyring of the platform,
+// which is the credential manager on windows.
+func StoreTokenInKeyring(token string) error {
+	return writeKeyring(token)
+}
+
+// TokenFromKeyring reads the token stored by StoreTokenInKeyring.
+func TokenFromKeyring() (string, error) {
+	return readKeyring()
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+// +build !windows
+
+package util
+
+func readKeyring() (string, error) {
+	return "", ErrKeyringUnsupported
+}
+
+func writeKeyring(token string) error {
+	return ErrKeyringUnsupported
+}
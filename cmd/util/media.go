@@ -0,0 +1,189 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+)
+
+// AttachmentTypes are the types of attachments which `--type` accepts besides mime types like `text/plain`.
+var AttachmentTypes = []string{"image", "pdf", "video", "audio", "document"}
+
+var documentTypes = map[string]bool{
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+	"application/vnd.ms-excel":                true,
+	"application/vnd.ms-powerpoint":           true,
+	"application/vnd.oasis.opendocument.text": true,
+	"text/plain":    true,
+	"text/markdown": true,
+	"text/csv":      true,
+}
+
+// attachmentMime returns the mime type of the attachment, or the one of the extension of its name.
+func attachmentMime(a todoist.FileAttachment) string {
+	t := a.FileType
+	if len(t) == 0 {
+		t = mime.TypeByExtension(strings.ToLower(path.Ext(a.FileName)))
+	}
+	if i := strings.Index(t, ";"); i >= 0 {
+		t = t[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// AttachmentKind returns the type of the attachment, one of AttachmentTypes, or its mime type otherwise.
+func AttachmentKind(a todoist.FileAttachment) string {
+	t := attachmentMime(a)
+	switch {
+	case strings.HasPrefix(t, "image/"):
+		return "image"
+	case t == "application/pdf":
+		return "pdf"
+	case strings.HasPrefix(t, "video/"):
+		return "video"
+	case strings.HasPrefix(t, "audio/"):
+		return "audio"
+	case documentTypes[t]:
+		return "document"
+	case len(t) == 0:
+		return "file"
+	}
+	return t
+}
+
+// HasAttachment reports whether the note has an attachment of the type, any type when it is empty.
+// The type is one of AttachmentTypes or a mime type.
+func HasAttachment(n todoist.Note, kind string) bool {
+	a := n.FileAttachment
+	if len(a.FileURL) == 0 && len(a.FileName) == 0 {
+		return false
+	}
+	if len(kind) == 0 {
+		return true
+	}
+	kind = strings.ToLower(kind)
+	return AttachmentKind(a) == kind || attachmentMime(a) == kind
+}
+
+// CheckAttachmentType returns an error unless the type is one of AttachmentTypes or a mime type.
+func CheckAttachmentType(kind string) error {
+	for _, t := range AttachmentTypes {
+		if strings.EqualFold(t, kind) {
+			return nil
+		}
+	}
+	if strings.Contains(kind, "/") {
+		return nil
+	}
+	return fmt.Errorf("invalid attachment type: %s, choose one of %s or a mime type", kind, strings.Join(AttachmentTypes, ", "))
+}
+
+// FilterItemsByAttachment returns the items which have a note with an attachment of the type.
+func FilterItemsByAttachment(client *todoist.Client, items []todoist.Item, kind string) []todoist.Item {
+	notes := client.Note.GroupByItem()
+	var res []todoist.Item
+	for _, i := range items {
+		for _, n := range notes[i.ID] {
+			if HasAttachment(n, kind) {
+				res = append(res, i)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// Media is an attachment of a note of an item or a project.
+type Media struct {
+	todoist.FileAttachment
+	Kind      string     `json:"kind"`
+	NoteID    todoist.ID `json:"note_id"`
+	ItemID    todoist.ID `json:"item_id,omitempty"`
+	ProjectID todoist.ID `json:"project_id"`
+	// Item is the content of the item, empty for project notes.
+	Item   string       `json:"item,omitempty"`
+	Posted todoist.Time `json:"posted"`
+}
+
+// ListMedia returns the attachments of the type in the projects, of all projects when projects is empty,
+// from the newest.
+func ListMedia(client *todoist.Client, projects map[todoist.ID]bool, kind string) []Media {
+	var res []Media
+	add := func(n todoist.Note, item *todoist.Item) {
+		if !HasAttachment(n, kind) {
+			return
+		}
+		m := Media{
+			FileAttachment: n.FileAttachment,
+			Kind:           AttachmentKind(n.FileAttachment),
+			NoteID:         n.ID,
+			ItemID:         n.ItemID,
+			ProjectID:      n.ProjectID,
+			Posted:         n.Posted,
+		}
+		if item != nil {
+			m.ProjectID = item.ProjectID
+			m.Item = item.Content
+		}
+		if len(projects) == 0 || projects[m.ProjectID] {
+			res = append(res, m)
+		}
+	}
+	notes := client.Note.GroupByItem()
+	for _, i := range client.Item.GetAll() {
+		i := i
+		for _, n := range notes[i.ID] {
+			add(n, &i)
+		}
+	}
+	for _, n := range client.ProjectNote.GetAll() {
+		add(n, nil)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Posted.After(res[j].Posted)
+	})
+	return res
+}
+
+// MediaOutput has a row for each attachment with its item, or `-` for project notes.
+func MediaOutput(media []Media, projects map[todoist.ID]todoist.Project) Output {
+	if media == nil {
+		media = []Media{}
+	}
+	var rows [][]todoist.ColorStringer
+	for _, m := range media {
+		project := m.ProjectID.String()
+		if p, ok := projects[m.ProjectID]; ok {
+			project = p.String()
+		}
+		item := m.Item
+		if m.ItemID.IsZero() {
+			item = "-"
+		}
+		size := ""
+		if m.FileSize > 0 {
+			size = formatSize(int64(m.FileSize))
+		}
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(m.NoteID.String()),
+			m.Posted,
+			todoist.NewNoColorString(m.Kind),
+			todoist.NewNoColorString(size),
+			todoist.NewNoColorString(project),
+			todoist.NewNoColorString(item),
+			todoist.NewNoColorString(m.FileName),
+			todoist.NewNoColorString(m.FileURL),
+		})
+	}
+	return Output{
+		Columns: []string{"note_id", "posted", "type", "size", "project", "item", "name", "url"},
+		Rows:    rows,
+		Data:    media,
+	}
+}
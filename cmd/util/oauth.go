@@ -0,0 +1,99 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+const (
+	oauthAuthorizeURL = "https://todoist.com/oauth/authorize"
+	oauthTokenURL     = "https://todoist.com/oauth/access_token"
+	// OAuthRedirectURL is where the browser comes back after signing in.
+	// Register it as the redirect url of your app.
+	OAuthRedirectURL = "http://localhost:8721/callback"
+)
+
+// OAuthToken signs in with the browser through the app of clientID and returns its access token.
+func OAuthToken(ctx context.Context, clientID, clientSecret string) (string, error) {
+	redirect, err := url.Parse(OAuthRedirectURL)
+	if err != nil {
+		return "", err
+	}
+	ln, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return "", err
+	}
+	state := string(todoist.GenerateUUID())
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != redirect.Path {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("state") != state {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+		if e := r.FormValue("error"); len(e) != 0 {
+			fmt.Fprintln(w, "failed to sign in, see the terminal")
+			select {
+			case errs <- fmt.Errorf("failed to sign in: %s", e):
+			default:
+			}
+			return
+		}
+		fmt.Fprintln(w, "signed in, you can close this window")
+		select {
+		case codes <- r.FormValue("code"):
+		default:
+		}
+	})}
+	go server.Serve(ln)
+	defer server.Close()
+
+	values := url.Values{
+		"client_id": {clientID},
+		"scope":     {"data:read_write,data:delete"},
+		"state":     {state},
+	}
+	fmt.Printf("open the url in your browser to sign in:\n%s?%s\n", oauthAuthorizeURL, values.Encode())
+	var code string
+	select {
+	case code = <-codes:
+	case err = <-errs:
+		return "", err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	res, err := http.PostForm(oauthTokenURL, url.Values{
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"code":          {code},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if (res.StatusCode / 100) != 2 {
+		return "", fmt.Errorf("failed to get the access token, status code: %d", res.StatusCode)
+	}
+	var out struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	if len(out.AccessToken) == 0 {
+		return "", errors.New("failed to get the access token: " + out.Error)
+	}
+	return out.AccessToken, nil
+}
@@ -0,0 +1,198 @@
+package util
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// OutputFormat is the format of the global `--output` flag.
+var OutputFormat = "table"
+
+// Output is what a command prints: rows for the table and csv,
+// and the resources themselves for json and templates.
+type Output struct {
+	Columns []string
+	Rows    [][]todoist.ColorStringer
+	Data    interface{}
+	// items are set by the outputs of items, which the formats for editors and launchers require.
+	items     []todoist.Item
+	relations todoist.ItemRelations
+}
+
+// OutputWriter writes an output in a format.
+type OutputWriter interface {
+	Write(w io.Writer, o Output) error
+}
+
+type TableWriter struct{}
+
+func (TableWriter) Write(w io.Writer, o Output) error {
+	_, err := fmt.Fprintln(w, TableString(o.Rows))
+	return err
+}
+
+type CSVWriter struct{}
+
+func (CSVWriter) Write(w io.Writer, o Output) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(o.Columns); err != nil {
+		return err
+	}
+	for _, row := range o.Rows {
+		var record []string
+		for _, c := range row {
+			record = append(record, strings.TrimSpace(c.String()))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type JSONWriter struct{}
+
+func (JSONWriter) Write(w io.Writer, o Output) error {
+	b, err := json.MarshalIndent(o.Data, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
+
+// TemplateWriter executes a text/template with the resources, e.g. `{{range .}}{{.Content}}{{"\n"}}{{end}}`.
+type TemplateWriter struct {
+	Template *template.Template
+}
+
+func (t TemplateWriter) Write(w io.Writer, o Output) error {
+	return t.Template.Execute(w, o.Data)
+}
+
+type VimgrepWriter struct{}
+
+func (VimgrepWriter) Write(w io.Writer, o Output) error {
+	if o.items == nil {
+		return fmt.Errorf("vimgrep output supports items only")
+	}
+	_, err := fmt.Fprintln(w, VimgrepString(o.items, o.relations))
+	return err
+}
+
+type ScriptFilterWriter struct{}
+
+func (ScriptFilterWriter) Write(w io.Writer, o Output) error {
+	if o.items == nil {
+		return fmt.Errorf("scriptfilter output supports items only")
+	}
+	s, err := ScriptFilterString(o.items, o.relations)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, s)
+	return err
+}
+
+// NewOutputWriter returns the writer of the format:
+// table, csv, json, vimgrep, scriptfilter or template=TEMPLATE.
+func NewOutputWriter(format string) (OutputWriter, error) {
+	switch {
+	case format == "table":
+		return TableWriter{}, nil
+	case format == "csv":
+		return CSVWriter{}, nil
+	case format == "json":
+		return JSONWriter{}, nil
+	case format == "vimgrep":
+		return VimgrepWriter{}, nil
+	case format == "scriptfilter":
+		return ScriptFilterWriter{}, nil
+	case strings.HasPrefix(format, "template="):
+		t, err := template.New("output").Parse(strings.TrimPrefix(format, "template="))
+		if err != nil {
+			return nil, err
+		}
+		return TemplateWriter{t}, nil
+	}
+	return nil, fmt.Errorf("unknown output format: %s", format)
+}
+
+// Print writes the output to stdout in the format of the `--output` flag.
+func Print(o Output) error {
+	w, err := NewOutputWriter(OutputFormat)
+	if err != nil {
+		return err
+	}
+	if err = w.Write(os.Stdout, o); err != nil {
+		return err
+	}
+	if _, ok := w.(TableWriter); ok {
+		for _, note := range []string{FocusNote(), StaleNote()} {
+			if len(note) != 0 {
+				fmt.Fprintf(os.Stderr, "(%s)\n", note)
+			}
+		}
+	}
+	return nil
+}
+
+func ItemsOutput(items []todoist.Item, relations todoist.ItemRelations, f func(item todoist.Item) todoist.Time) Output {
+	if items == nil {
+		items = []todoist.Item{}
+	}
+	return Output{
+		Columns:   []string{"id", "date", "priority", "project", "labels", "content"},
+		Rows:      itemRows(items, relations, f),
+		Data:      items,
+		items:     items,
+		relations: relations,
+	}
+}
+
+// AliasedItemsOutput is ItemsOutput with a `#N` alias column after the id.
+func AliasedItemsOutput(items []todoist.Item, relations todoist.ItemRelations, f func(item todoist.Item) todoist.Time) Output {
+	o := ItemsOutput(items, relations, f)
+	o.Columns = append([]string{"id", "alias"}, o.Columns[1:]...)
+	for i, row := range o.Rows {
+		alias := todoist.NewNoColorString("#" + strconv.Itoa(i+1))
+		o.Rows[i] = append([]todoist.ColorStringer{row[0], alias}, row[1:]...)
+	}
+	return o
+}
+
+func ProjectsOutput(projects []todoist.Project) Output {
+	if projects == nil {
+		projects = []todoist.Project{}
+	}
+	return Output{Columns: []string{"id", "name"}, Rows: projectRows(projects), Data: projects}
+}
+
+func LabelsOutput(labels []todoist.Label) Output {
+	if labels == nil {
+		labels = []todoist.Label{}
+	}
+	return Output{Columns: []string{"id", "name"}, Rows: labelRows(labels), Data: labels}
+}
+
+func FiltersOutput(filters []todoist.Filter) Output {
+	if filters == nil {
+		filters = []todoist.Filter{}
+	}
+	return Output{Columns: []string{"id", "name", "query"}, Rows: filterRows(filters), Data: filters}
+}
+
+func NotesOutput(notes []todoist.Note) Output {
+	if notes == nil {
+		notes = []todoist.Note{}
+	}
+	return Output{Columns: []string{"id", "posted", "content"}, Rows: noteRows(notes), Data: notes}
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+	"strings"
+)
+
+// OfferPendingOnce makes the first client of this run offer the pending commands by OfferPending.
+var OfferPendingOnce bool
+
+// OfferPending offers to retry the commands kept by a failed commit of a former run,
+// or to discard them. Without a terminal, it only tells about them on stderr.
+func OfferPending(client *todoist.Client) error {
+	p, err := client.Pending()
+	if err != nil || p == nil {
+		return err
+	}
+	msg := fmt.Sprintf("%d unsent command(s) of a commit failed at %s (%s)",
+		len(p.Commands), p.FailedAt.Local().Format("2006-01-02 15:04"), pendingTypes(p.Commands))
+	if !IsTerminal(os.Stdin) || !IsTerminal(os.Stdout) {
+		fmt.Fprintf(os.Stderr, "%s, run todoist sync to retry them\n", msg)
+		return nil
+	}
+	fmt.Println(msg)
+	if Confirm("retry them now?") {
+		if err = client.RetryPending(context.Background()); err != nil {
+			// the command goes on, the commands are kept for the next run unless the server rejected them
+			if p, _ := client.Pending(); p != nil {
+				fmt.Fprintf(os.Stderr, "failed to retry, keep them for the next run: %s\n", err)
+			} else {
+				fmt.Fprintf(os.Stderr, "failed to retry, discard them: %s\n", err)
+			}
+			return nil
+		}
+		fmt.Printf("succeeded to retry %d command(s)\n", len(p.Commands))
+		return nil
+	}
+	if Confirm("discard them?") {
+		return client.DiscardPending()
+	}
+	return nil
+}
+
+// pendingTypes returns the types of the commands without duplicates, such as `item_add, note_add`.
+func pendingTypes(commands []todoist.Command) string {
+	var types []string
+	seen := map[string]bool{}
+	for _, c := range commands {
+		if !seen[c.Type] {
+			seen[c.Type] = true
+			types = append(types, c.Type)
+		}
+	}
+	return strings.Join(types, ", ")
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"errors"
+	"fmt"
 	"github.com/kobtea/go-todoist/todoist"
 )
 
@@ -32,3 +33,25 @@ func ProcessIDs(ids []string, f func([]todoist.ID) error) error {
 	}
 	return nil
 }
+
+// ResolveProjectID accepts a project id or name and returns the id of the project.
+func ResolveProjectID(client *todoist.Client, idOrName string) (todoist.ID, error) {
+	if id, err := todoist.NewID(idOrName); err == nil {
+		return id, nil
+	}
+	if project := client.Project.FindOneByName(idOrName); project != nil {
+		return project.ID, nil
+	}
+	return "", fmt.Errorf("no such project: %s", idOrName)
+}
+
+// ResolveSectionID accepts a section id or name in the given project and returns the id of the section.
+func ResolveSectionID(client *todoist.Client, projectID todoist.ID, idOrName string) (todoist.ID, error) {
+	if id, err := todoist.NewID(idOrName); err == nil {
+		return id, nil
+	}
+	if section := client.Section.FindOneByName(projectID, idOrName); section != nil {
+		return section.ID, nil
+	}
+	return "", fmt.Errorf("no such section: %s", idOrName)
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+var processStart = time.Now()
+
+// Profile records how long each step of the startup took. It is enabled by `--profile-startup`.
+type Profile struct {
+	Enabled bool
+	last    time.Time
+	steps   []profileStep
+}
+
+type profileStep struct {
+	name string
+	d    time.Duration
+}
+
+var StartupProfile = &Profile{}
+
+// Mark records the time since the previous step as the step of the name.
+func (p *Profile) Mark(name string) {
+	if !p.Enabled {
+		return
+	}
+	now := time.Now()
+	if p.last.IsZero() {
+		p.last = processStart
+	}
+	p.steps = append(p.steps, profileStep{name, now.Sub(p.last)})
+	p.last = now
+}
+
+// Write records each line logged by the client, such as decoding a resource of the cache, as a step.
+func (p *Profile) Write(b []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		p.Mark(line)
+	}
+	return len(b), nil
+}
+
+func (p *Profile) logger() *log.Logger {
+	if !p.Enabled {
+		return nil
+	}
+	return log.New(p, "", 0)
+}
+
+func (p *Profile) Print(w io.Writer) {
+	if !p.Enabled {
+		return
+	}
+	var buf bytes.Buffer
+	var total time.Duration
+	for _, s := range p.steps {
+		fmt.Fprintf(&buf, "%10s  %s\n", s.d.Round(time.Microsecond), s.name)
+		total += s.d
+	}
+	fmt.Fprintf(&buf, "%10s  total\n", total.Round(time.Microsecond))
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/mattn/go-isatty"
+	"os"
+	"strings"
+)
+
+// stdin is shared by the prompts, so that answers piped at once are not lost
+// in the buffer of a former prompt.
+var stdin = bufio.NewReader(os.Stdin)
+
+// Confirm asks a yes/no question and reports whether it was answered with yes.
+// The answer may end with CRLF, as the console of windows sends.
+func Confirm(msg string) bool {
+	ans, ok := Prompt(fmt.Sprintf("%s (y/[n])", msg))
+	return ok && ans == "y"
+}
+
+// Prompt asks for a line and returns it without the line ending.
+// It reports false when stdin is closed before an answer.
+func Prompt(msg string) (string, bool) {
+	fmt.Printf("%s: ", msg)
+	ans, err := stdin.ReadString('\n')
+	if err != nil && len(ans) == 0 {
+		return "", false
+	}
+	return strings.TrimRight(ans, "\r\n"), true
+}
+
+// IsTerminal reports whether f is a terminal rather than a pipe or a file.
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
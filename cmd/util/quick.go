@@ -0,0 +1,131 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Route sends quick added items to Project during Days and Hours, e.g.
+// `{"days": "mon-fri", "hours": "9-18", "project": "Work"}`. Empty Days or Hours match any time,
+// so a route with neither is a fallback.
+type Route struct {
+	// Days is a comma separated list of days or ranges of days, such as `mon-fri,sun`.
+	Days string `json:"days,omitempty"`
+	// Hours is a range of hours in 24h, such as `9-18`. The end is exclusive and may wrap midnight.
+	Hours   string `json:"hours,omitempty"`
+	Project string `json:"project"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Match reports whether the route applies at t.
+func (r Route) Match(t time.Time) (bool, error) {
+	if len(r.Days) != 0 {
+		ok, err := matchDays(r.Days, t.Weekday())
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	if len(r.Hours) != 0 {
+		var from, to int
+		if n, err := fmt.Sscanf(r.Hours, "%d-%d", &from, &to); err != nil || n != 2 || from < 0 || from > 24 || to < 0 || to > 24 {
+			return false, fmt.Errorf("invalid hours of route: %s", r.Hours)
+		}
+		h := t.Hour()
+		if from <= to {
+			return from <= h && h < to, nil
+		}
+		return from <= h || h < to, nil
+	}
+	return true, nil
+}
+
+func matchDays(days string, d time.Weekday) (bool, error) {
+	day := func(s string) (time.Weekday, error) {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if len(s) > 3 {
+			s = s[:3]
+		}
+		if w, ok := weekdays[s]; ok {
+			return w, nil
+		}
+		return 0, fmt.Errorf("invalid day of route: %s", days)
+	}
+	for _, part := range strings.Split(days, ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		from, err := day(bounds[0])
+		if err != nil {
+			return false, err
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = day(bounds[1]); err != nil {
+				return false, err
+			}
+		}
+		// a range such as fri-mon wraps the weekend
+		if from <= to && from <= d && d <= to || from > to && (from <= d || d <= to) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// QuickAdd is an item written in the quick add syntax, such as
+// `Write the report #Work /Triage @deep p1`.
+type QuickAdd struct {
+	Content  string
+	Project  string
+	Section  string
+	Labels   []string
+	Priority int
+	// Routed reports whether the project is chosen by a route.
+	Routed bool
+}
+
+// ParseQuickAdd parses text in the quick add syntax. When text has no project,
+// the project of the first route matching now is taken.
+func ParseQuickAdd(text string, routes []Route, now time.Time) (*QuickAdd, error) {
+	var q QuickAdd
+	var words []string
+	for _, w := range strings.Fields(text) {
+		lower := strings.ToLower(w)
+		switch {
+		case len(w) > 1 && w[0] == '#':
+			q.Project = w[1:]
+		case len(w) > 1 && w[0] == '/':
+			q.Section = w[1:]
+		case len(w) > 1 && w[0] == '@':
+			q.Labels = append(q.Labels, w[1:])
+		case len(w) == 2 && lower[0] == 'p' && '1' <= lower[1] && lower[1] <= '4':
+			// p1 is the highest priority, which is 4 in the api.
+			p, _ := strconv.Atoi(lower[1:])
+			q.Priority = 5 - p
+		default:
+			words = append(words, w)
+		}
+	}
+	q.Content = strings.Join(words, " ")
+	if len(q.Content) == 0 {
+		return nil, fmt.Errorf("require a content: %s", text)
+	}
+	if len(q.Project) == 0 {
+		for _, r := range routes {
+			ok, err := r.Match(now)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				q.Project = r.Project
+				q.Routed = true
+				break
+			}
+		}
+	}
+	return &q, nil
+}
@@ -0,0 +1,274 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// RateLimitConfig is the budget of requests shared by the daemon and the commands run with the same token.
+// The daemon leaves Reserve requests of each window to the commands, so that its polling never starves them.
+type RateLimitConfig struct {
+	// Requests is the number of requests in a window, 50 by default.
+	Requests int `json:"requests,omitempty"`
+	// Window is a duration like `1m`, the default.
+	Window string `json:"window,omitempty"`
+	// Reserve is the number of requests left to the commands, 10 by default.
+	Reserve int `json:"reserve,omitempty"`
+}
+
+// RateLimit is the rate limit of the config.
+var RateLimit RateLimitConfig
+
+// Background marks the requests of this run as background ones, such as the syncs of the daemon.
+var Background bool
+
+func (c RateLimitConfig) limits() (int, time.Duration, int, error) {
+	requests, reserve := c.Requests, c.Reserve
+	if requests <= 0 {
+		requests = 50
+	}
+	if reserve <= 0 {
+		reserve = 10
+	}
+	if reserve >= requests {
+		reserve = requests - 1
+	}
+	window := time.Minute
+	if len(c.Window) != 0 {
+		d, err := time.ParseDuration(c.Window)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if d <= 0 {
+			return 0, 0, 0, fmt.Errorf("rate limit window must be positive: %s", c.Window)
+		}
+		window = d
+	}
+	return requests, window, reserve, nil
+}
+
+// lease is the use of the budget of a token, kept in a file shared by all the processes.
+type lease struct {
+	// Requests are the times of the requests within the window.
+	Requests []time.Time `json:"requests"`
+	// Background are the times of the requests of the daemon within the window.
+	Background []time.Time `json:"background"`
+	// BlockedUntil is when the server allows requests again after answering 429.
+	BlockedUntil time.Time `json:"blocked_until,omitempty"`
+}
+
+func (l *lease) prune(now time.Time, window time.Duration) {
+	keep := func(ts []time.Time) []time.Time {
+		var res []time.Time
+		for _, t := range ts {
+			if now.Sub(t) < window {
+				res = append(res, t)
+			}
+		}
+		return res
+	}
+	l.Requests = keep(l.Requests)
+	l.Background = keep(l.Background)
+}
+
+// Budget is the use of the rate limit budget of a token.
+type Budget struct {
+	Used       int
+	Background int
+	Requests   int
+	Reserve    int
+	Window     time.Duration
+	// BlockedUntil is when the server allows requests again, zero unless it answered 429.
+	BlockedUntil time.Time
+}
+
+// Left returns the number of requests left to the commands.
+func (b Budget) Left() int {
+	if b.Used >= b.Requests {
+		return 0
+	}
+	return b.Requests - b.Used
+}
+
+// BackgroundLeft returns the number of requests left to the daemon.
+func (b Budget) BackgroundLeft() int {
+	if n := b.Left() - b.Reserve; n > 0 {
+		return n
+	}
+	return 0
+}
+
+func (b Budget) String() string {
+	s := fmt.Sprintf("%d/%d requests left in %s (daemon %d, %d reserved for commands)",
+		b.Left(), b.Requests, b.Window, b.BackgroundLeft(), b.Reserve)
+	if time.Now().Before(b.BlockedUntil) {
+		s += fmt.Sprintf(", blocked by the server until %s", b.BlockedUntil.Format("15:04:05"))
+	}
+	return s
+}
+
+// budgetFile returns the lease file of the token, shared by the configs using it.
+func budgetFile(token string) (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256([]byte(token))
+	return filepath.Join(dir, "ratelimit-"+hex.EncodeToString(h[:4])+".json"), nil
+}
+
+// withLease runs f on the lease of the token while holding its lock, and writes the lease back.
+func withLease(token string, f func(l *lease) error) error {
+	file, err := budgetFile(token)
+	if err != nil {
+		return err
+	}
+	lock := file + ".lock"
+	for deadline := time.Now().Add(time.Second); ; {
+		fd, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			fd.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+		// a lock left by a killed process
+		if fi, err := os.Stat(lock); err == nil && time.Since(fi.ModTime()) > 10*time.Second {
+			os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("rate limit budget is locked: %s", lock)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer os.Remove(lock)
+	var l lease
+	if b, err := ioutil.ReadFile(file); err == nil {
+		// a broken lease starts over
+		json.Unmarshal(b, &l)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err = f(&l); err != nil {
+		return err
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, b, 0600)
+}
+
+// ReadBudget returns the current use of the budget of the token.
+func ReadBudget(token string) (*Budget, error) {
+	requests, window, reserve, err := RateLimit.limits()
+	if err != nil {
+		return nil, err
+	}
+	b := &Budget{Requests: requests, Window: window, Reserve: reserve}
+	err = withLease(token, func(l *lease) error {
+		l.prune(time.Now(), window)
+		b.Used = len(l.Requests)
+		b.Background = len(l.Background)
+		b.BlockedUntil = l.BlockedUntil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// budgetTransport takes a request of the budget of the token before each request,
+// and waits while none is left to this run.
+type budgetTransport struct {
+	base       http.RoundTripper
+	token      string
+	background bool
+}
+
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.acquire(req); err != nil {
+		return nil, err
+	}
+	res, err := t.base.RoundTrip(req)
+	if err == nil && res.StatusCode == http.StatusTooManyRequests {
+		t.block(res.Header.Get("Retry-After"))
+	}
+	return res, err
+}
+
+func (t *budgetTransport) acquire(req *http.Request) error {
+	requests, window, reserve, err := RateLimit.limits()
+	if err != nil {
+		return err
+	}
+	limit := requests
+	if t.background {
+		limit -= reserve
+	}
+	for {
+		var wait time.Duration
+		err := withLease(t.token, func(l *lease) error {
+			now := time.Now()
+			l.prune(now, window)
+			if now.Before(l.BlockedUntil) {
+				wait = l.BlockedUntil.Sub(now)
+				return nil
+			}
+			if len(l.Requests) >= limit {
+				// the oldest request leaves the window first
+				wait = window - now.Sub(l.Requests[len(l.Requests)-limit])
+				return nil
+			}
+			l.Requests = append(l.Requests, now)
+			if t.background {
+				l.Background = append(l.Background, now)
+			}
+			return nil
+		})
+		if err != nil || wait <= 0 {
+			return err
+		}
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return req.Context().Err()
+		}
+	}
+}
+
+// block stops the requests of all the processes for the retry-after seconds of a 429, a window by default.
+func (t *budgetTransport) block(retryAfter string) {
+	_, window, _, err := RateLimit.limits()
+	if err != nil {
+		return
+	}
+	d := window
+	if n, err := strconv.Atoi(retryAfter); err == nil && n > 0 {
+		d = time.Duration(n) * time.Second
+	}
+	withLease(t.token, func(l *lease) error {
+		l.BlockedUntil = time.Now().Add(d)
+		return nil
+	})
+}
+
+// budgetClient returns a http client taking the budget of the token for each request.
+func budgetClient(token string) *http.Client {
+	return &http.Client{Transport: &budgetTransport{
+		base:       http.DefaultTransport,
+		token:      token,
+		background: Background,
+	}}
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !windows
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!windows
+
+package util
+
+import (
+	"errors"
+	"os"
+)
+
+func makeRaw(f *os.File) (func(), error) {
+	return nil, errors.New("raw mode of the terminal is not supported")
+}
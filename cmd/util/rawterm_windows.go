@@ -0,0 +1,21 @@
+package util
+
+import (
+	"golang.org/x/sys/windows"
+	"os"
+)
+
+// makeRaw puts the console of f into the mode which reads keys as escape sequences
+// without echo and line buffering, and returns the function to restore it.
+func makeRaw(f *os.File) (func(), error) {
+	h := windows.Handle(f.Fd())
+	var old uint32
+	if err := windows.GetConsoleMode(h, &old); err != nil {
+		return nil, err
+	}
+	raw := old &^ (windows.ENABLE_ECHO_INPUT | windows.ENABLE_LINE_INPUT | windows.ENABLE_PROCESSED_INPUT)
+	if err := windows.SetConsoleMode(h, raw|windows.ENABLE_VIRTUAL_TERMINAL_INPUT); err != nil {
+		return nil, err
+	}
+	return func() { windows.SetConsoleMode(h, old) }, nil
+}
@@ -0,0 +1,123 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+	"sort"
+	"time"
+)
+
+// Touch is an item viewed, edited or commented at a time.
+type Touch struct {
+	ItemID todoist.ID `json:"item_id"`
+	Action string     `json:"action"`
+	At     time.Time  `json:"at"`
+}
+
+const auditState = "audit"
+
+// auditLimit is the number of the latest touches kept in the audit log.
+const auditLimit = 500
+
+// Audit records the items touched by the action, such as viewed, in the local audit log.
+func Audit(action string, ids ...todoist.ID) error {
+	touches, err := ReadAudit()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, id := range ids {
+		touches = append(touches, Touch{ItemID: id, Action: action, At: now})
+	}
+	if len(touches) > auditLimit {
+		touches = touches[len(touches)-auditLimit:]
+	}
+	return WriteState(auditState, touches)
+}
+
+// AuditChange records the items of a committed change or a view like Audit. A failure is told on stderr
+// instead of returned, as the audit log must not fail the command.
+func AuditChange(action string, ids ...todoist.ID) {
+	if err := Audit(action, ids...); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write the audit log: %s\n", err)
+	}
+}
+
+// ReadAudit returns the touches of the local audit log, the oldest first.
+func ReadAudit() ([]Touch, error) {
+	var touches []Touch
+	if err := ReadState(auditState, &touches); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return touches, nil
+}
+
+// EventTouches returns the touches of items in the activity events initiated by the user.
+// An event of a note touches its item as commented. An empty userID takes events of anyone.
+func EventTouches(events []todoist.Event, userID todoist.ID) []Touch {
+	var res []Touch
+	for _, e := range events {
+		if !userID.IsZero() && !e.InitiatorID.IsZero() && e.InitiatorID != userID {
+			continue
+		}
+		switch e.ObjectType {
+		case "item":
+			res = append(res, Touch{ItemID: e.ObjectID, Action: e.EventType, At: e.EventDate.Time})
+		case "note":
+			if !e.ParentItemID.IsZero() {
+				res = append(res, Touch{ItemID: e.ParentItemID, Action: "commented", At: e.EventDate.Time})
+			}
+		}
+	}
+	return res
+}
+
+// RecentItem is an item with its latest touch.
+type RecentItem struct {
+	todoist.Item
+	Touch Touch `json:"touch"`
+}
+
+// RecentItems returns the cached items by their latest touch, the latest first, up to limit.
+func RecentItems(client *todoist.Client, touches []Touch, limit int) []RecentItem {
+	latest := map[todoist.ID]Touch{}
+	for _, t := range touches {
+		if l, ok := latest[t.ItemID]; !ok || t.At.After(l.At) {
+			latest[t.ItemID] = t
+		}
+	}
+	var res []RecentItem
+	for id, t := range latest {
+		if i := client.Item.Resolve(id); i != nil {
+			res = append(res, RecentItem{Item: *i, Touch: t})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Touch.At.After(res[j].Touch.At)
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
+func RecentOutput(items []RecentItem, relations todoist.ItemRelations) Output {
+	if items == nil {
+		items = []RecentItem{}
+	}
+	var plain []todoist.Item
+	for _, i := range items {
+		plain = append(plain, i.Item)
+	}
+	o := ItemsOutput(plain, relations, func(i todoist.Item) todoist.Time { return i.Due.Date })
+	o.Columns = append([]string{"touched", "action"}, o.Columns...)
+	for n, row := range o.Rows {
+		o.Rows[n] = append([]todoist.ColorStringer{
+			todoist.NewNoColorString(items[n].Touch.At.Local().Format("01-02 15:04")),
+			todoist.NewNoColorString(items[n].Touch.Action),
+		}, row...)
+	}
+	o.Data = items
+	return o
+}
@@ -0,0 +1,114 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cycle results of a recurring item, from the oldest.
+const (
+	CycleOnTime  = "on-time"
+	CycleLate    = "late"
+	CycleSkipped = "skipped"
+)
+
+// RecurringReport is how a recurring item was completed over its past cycles.
+type RecurringReport struct {
+	Item       todoist.Item `json:"item"`
+	Recurrence string       `json:"recurrence"`
+	Cycles     []string     `json:"cycles"`
+	OnTime     int          `json:"on_time"`
+	Late       int          `json:"late"`
+	Skipped    int          `json:"skipped"`
+}
+
+// Adherence is the ratio of the cycles completed on time.
+func (r RecurringReport) Adherence() float64 {
+	if len(r.Cycles) == 0 {
+		return 0
+	}
+	return float64(r.OnTime) / float64(len(r.Cycles))
+}
+
+// NewRecurringReport classifies the n cycles before the current due date of the item.
+// A cycle is on time when it was completed after the due date of the former cycle
+// until the end of its own due date, and late when completed until the next due date.
+// Each completion counts for one cycle.
+func NewRecurringReport(item todoist.Item, completions []time.Time, n int) (RecurringReport, error) {
+	report := RecurringReport{Item: item}
+	r, err := todoist.ParseRecurrence(item.Due.String)
+	if err != nil {
+		return report, err
+	}
+	report.Recurrence = r.String()
+	if item.Due.Date.IsZero() {
+		return report, fmt.Errorf("no due date: %s", item.Content)
+	}
+	sort.Slice(completions, func(i, j int) bool { return completions[i].Before(completions[j]) })
+	used := make([]bool, len(completions))
+	take := func(from, until time.Time) bool {
+		for i, c := range completions {
+			if !used[i] && c.After(from) && !c.After(until) {
+				used[i] = true
+				return true
+			}
+		}
+		return false
+	}
+	current := item.Due.Date.EndOfDay(time.Local).Time
+	report.Cycles = make([]string, n)
+	// completions on time are matched first, so that a late one does not take them from the next cycle
+	for k := n; k >= 1; k-- {
+		if due := r.Before(current, k); take(r.Before(due, 1), due) {
+			report.Cycles[n-k] = CycleOnTime
+			report.OnTime++
+		}
+	}
+	for k := n; k >= 1; k-- {
+		if len(report.Cycles[n-k]) != 0 {
+			continue
+		}
+		if due := r.Before(current, k); take(due, r.Before(due, -1)) {
+			report.Cycles[n-k] = CycleLate
+			report.Late++
+		} else {
+			report.Cycles[n-k] = CycleSkipped
+			report.Skipped++
+		}
+	}
+	return report, nil
+}
+
+func cycleMarks(cycles []string) string {
+	marks := map[string]string{CycleOnTime: "o", CycleLate: "~", CycleSkipped: "x"}
+	var s []string
+	for _, c := range cycles {
+		s = append(s, marks[c])
+	}
+	return strings.Join(s, "")
+}
+
+func RecurringOutput(reports []RecurringReport) Output {
+	if reports == nil {
+		reports = []RecurringReport{}
+	}
+	var rows [][]todoist.ColorStringer
+	for _, r := range reports {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(r.Item.ID.String()),
+			todoist.NewNoColorString(r.Recurrence),
+			todoist.NewNoColorString(fmt.Sprintf("%d/%d/%d", r.OnTime, r.Late, r.Skipped)),
+			todoist.NewNoColorString(fmt.Sprintf("%.0f%%", r.Adherence()*100)),
+			todoist.NewNoColorString(cycleMarks(r.Cycles)),
+			todoist.NewNoColorString(r.Item.Content),
+		})
+	}
+	return Output{
+		Columns: []string{"id", "every", "on-time/late/skipped", "adherence", "history", "content"},
+		Rows:    rows,
+		Data:    reports,
+	}
+}
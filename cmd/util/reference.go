@@ -0,0 +1,17 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+)
+
+// ExpandReferences shows the content of each referenced item inline, such as
+// `[[123: buy milk]]`. A reference to an unknown item is kept as is.
+func ExpandReferences(client *todoist.Client, text string) string {
+	return todoist.ExpandReferences(text, func(id todoist.ID) string {
+		if item := client.Item.Resolve(id); item != nil {
+			return fmt.Sprintf("[[%s: %s]]", id, item.Content)
+		}
+		return ""
+	})
+}
@@ -0,0 +1,162 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ProjectReference is a reference to a project by its name in the config or the local state,
+// which a rename of the project breaks.
+type ProjectReference struct {
+	// Where is the place of the reference, such as `default_project` or `hooks["item add"]`.
+	Where  string
+	Before string
+	After  string
+}
+
+// namePattern matches a name as the third group. The name ends before one of the boundary characters,
+// which the pattern does not consume so that adjacent names match too.
+type namePattern struct {
+	re       *regexp.Regexp
+	boundary string
+}
+
+// projectNamePatterns match the name in a filter query, such as `#Work & today`, and in the project flags
+// of a command line, such as `--project Work` or `-p "Work"`.
+func projectNamePatterns(name string) []namePattern {
+	q := regexp.QuoteMeta(name)
+	return []namePattern{
+		{regexp.MustCompile(`(?i)(^|[^#\w])(##?)(` + q + `)`), " \t&|),"},
+		{regexp.MustCompile(`(?i)(^|\s)(--project[= ]|-p ?)("` + q + `"|` + q + `)`), " \t"},
+	}
+}
+
+// replaceProjectName returns s with the references to the project old renamed to new.
+func replaceProjectName(s, old, new string) string {
+	for _, p := range projectNamePatterns(old) {
+		var b strings.Builder
+		last := 0
+		for _, m := range p.re.FindAllStringSubmatchIndex(s, -1) {
+			end := m[1]
+			if end < len(s) && !strings.ContainsRune(p.boundary, rune(s[end])) {
+				continue
+			}
+			name := new
+			if s[m[6]] == '"' || (strings.Contains(new, " ") && s[m[4]] == '-') {
+				name = `"` + new + `"`
+			}
+			b.WriteString(s[last:m[6]])
+			b.WriteString(name)
+			last = end
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+	return s
+}
+
+// RenameProjectInConfig rewrites the references to the project old by name in the config to new:
+// the default project, the routes of quick and the hooks. It returns the rewritten references.
+func RenameProjectInConfig(c *Config, old, new string) []ProjectReference {
+	var refs []ProjectReference
+	if strings.EqualFold(c.DefaultProject, old) {
+		refs = append(refs, ProjectReference{"default_project", c.DefaultProject, new})
+		c.DefaultProject = new
+	}
+	for i, r := range c.Routes {
+		if strings.EqualFold(r.Project, old) {
+			refs = append(refs, ProjectReference{fmt.Sprintf("routes[%d].project", i), r.Project, new})
+			c.Routes[i].Project = new
+		}
+	}
+	var keys []string
+	for k := range c.Hooks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		hook := c.Hooks[k]
+		if s := replaceProjectName(hook, old, new); s != hook {
+			refs = append(refs, ProjectReference{fmt.Sprintf("hooks[%q]", k), hook, s})
+			c.Hooks[k] = s
+		}
+	}
+	return refs
+}
+
+// RenameProjectInFocus rewrites the reference to the project old in the query of the focus to new.
+// It returns nil when the focus has no reference.
+func RenameProjectInFocus(f *Focus, old, new string) *ProjectReference {
+	if f == nil {
+		return nil
+	}
+	q := replaceProjectName(f.Query, old, new)
+	if q == f.Query {
+		return nil
+	}
+	ref := &ProjectReference{"focus", f.Query, q}
+	f.Query = q
+	return ref
+}
+
+// ProjectReferencesString shows the references as `where before -> after` rows.
+func ProjectReferencesString(refs []ProjectReference) string {
+	var rows [][]todoist.ColorStringer
+	for _, r := range refs {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(r.Where),
+			todoist.NewNoColorString(r.Before),
+			todoist.NewNoColorString("->"),
+			todoist.NewNoColorString(r.After),
+		})
+	}
+	return TableString(rows)
+}
+
+// ProjectRename is the rewrite of the references to a renamed project in the config and the focus.
+type ProjectRename struct {
+	Refs   []ProjectReference
+	config *Config
+	focus  *Focus
+}
+
+// NewProjectRename finds the references to the project old in the config and the focus, to rename them to new.
+func NewProjectRename(old, new string) (*ProjectRename, error) {
+	r := &ProjectRename{}
+	c, err := LoadConfig()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if c != nil {
+		if refs := RenameProjectInConfig(c, old, new); len(refs) > 0 {
+			r.Refs = append(r.Refs, refs...)
+			r.config = c
+		}
+	}
+	f, err := LoadFocus()
+	if err != nil {
+		return nil, err
+	}
+	if ref := RenameProjectInFocus(f, old, new); ref != nil {
+		r.Refs = append(r.Refs, *ref)
+		r.focus = f
+	}
+	return r, nil
+}
+
+// Apply writes the rewritten config, replacing the file at once, and the focus.
+func (r *ProjectRename) Apply() error {
+	if r.config != nil {
+		if err := WriteConfig(r.config); err != nil {
+			return err
+		}
+	}
+	if r.focus != nil {
+		return WriteState(focusState, r.focus)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the json output formats. It is raised
+// when a field is removed or changes its type, but not when a field is added.
+const SchemaVersion = 1
+
+const schemaBaseURL = "https://github.com/kobtea/go-todoist/schema"
+
+// schemaTypes are the resources printed by `--output json`, as lists.
+var schemaTypes = map[string]reflect.Type{
+	"items":    reflect.TypeOf(todoist.Item{}),
+	"projects": reflect.TypeOf(todoist.Project{}),
+	"labels":   reflect.TypeOf(todoist.Label{}),
+	"filters":  reflect.TypeOf(todoist.Filter{}),
+	"notes":    reflect.TypeOf(todoist.Note{}),
+	"events":   reflect.TypeOf(todoist.Event{}),
+}
+
+// SchemaNames returns the names of the json outputs which have a schema.
+func SchemaNames() []string {
+	var names []string
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Schema returns the JSON Schema of the json output of the name, such as items.
+func Schema(name string) (map[string]interface{}, error) {
+	t, ok := schemaTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("no schema of %s, choose one of %s", name, strings.Join(SchemaNames(), ", "))
+	}
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$id":     fmt.Sprintf("%s/v%d/%s.json", schemaBaseURL, SchemaVersion, name),
+		"title":   name,
+		"type":    "array",
+		"items":   jsonSchema(t),
+	}, nil
+}
+
+var (
+	idType      = reflect.TypeOf(todoist.ID(""))
+	timeType    = reflect.TypeOf(todoist.Time{})
+	intBoolType = reflect.TypeOf(todoist.IntBool(false))
+	stdTimeType = reflect.TypeOf(time.Time{})
+)
+
+// jsonSchema describes t as encoding/json marshals it.
+func jsonSchema(t reflect.Type) map[string]interface{} {
+	switch t {
+	case idType:
+		// integers, temp ids like `$1` as strings, and null for none
+		return map[string]interface{}{"type": []string{"integer", "string", "null"}}
+	case timeType:
+		return map[string]interface{}{
+			"type":        []string{"string", "null"},
+			"description": "a date like 2006-01-02, or a date and time like 2006-01-02T15:04:05Z",
+		}
+	case intBoolType:
+		return map[string]interface{}{"type": "integer", "enum": []int{0, 1}}
+	case stdTimeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := jsonSchema(t.Elem())
+		return map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		var required []string
+		addStructFields(t, properties, &required)
+		s := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			sort.Strings(required)
+			s["required"] = required
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Interface:
+		return map[string]interface{}{}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	}
+	return map[string]interface{}{}
+}
+
+// addStructFields adds the fields of t to the properties, flattening embedded structs like encoding/json.
+// The fields without omitempty are always present, so they are required.
+func addStructFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		if f.Anonymous && len(opts[0]) == 0 && f.Type.Kind() == reflect.Struct {
+			addStructFields(f.Type, properties, required)
+			continue
+		}
+		name := opts[0]
+		if len(name) == 0 {
+			name = f.Name
+		}
+		properties[name] = jsonSchema(f.Type)
+		omitempty := false
+		for _, o := range opts[1:] {
+			omitempty = omitempty || o == "omitempty"
+		}
+		// encoding/json never omits structs
+		if !omitempty || f.Type.Kind() == reflect.Struct {
+			*required = append(*required, name)
+		}
+	}
+}
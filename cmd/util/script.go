@@ -0,0 +1,46 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ScriptCommand is a todoist command line of a plan, such as `todoist item update 123 --priority 3`,
+// with a comment to review it by, such as the content of the item.
+type ScriptCommand struct {
+	Comment string
+	Args    []string
+}
+
+var shellSafe = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+// ShellQuote quotes s for sh unless it consists of safe characters only.
+func ShellQuote(s string) string {
+	if len(s) != 0 && shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// ScriptString returns a sh script running the commands, which stops at the first failing one.
+// The title, such as the command line which planned them, is the comment at the top.
+func ScriptString(title string, commands []ScriptCommand) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	if len(title) != 0 {
+		b.WriteString("# " + title + "\n")
+	}
+	b.WriteString("set -e\n")
+	for _, c := range commands {
+		b.WriteString("\n")
+		if len(c.Comment) != 0 {
+			b.WriteString("# " + strings.Replace(c.Comment, "\n", " ", -1) + "\n")
+		}
+		words := []string{"todoist"}
+		for _, a := range c.Args {
+			words = append(words, ShellQuote(a))
+		}
+		b.WriteString(strings.Join(words, " ") + "\n")
+	}
+	return b.String()
+}
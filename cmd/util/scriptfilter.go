@@ -0,0 +1,58 @@
+package util
+
+import (
+	"encoding/json"
+	"github.com/kobtea/go-todoist/todoist"
+	"strconv"
+	"strings"
+)
+
+// scriptFilterItem is an entry of the Alfred script filter format,
+// which Raycast and rofi wrappers read as well.
+type scriptFilterItem struct {
+	UID      string           `json:"uid"`
+	Title    string           `json:"title"`
+	Subtitle string           `json:"subtitle"`
+	Arg      string           `json:"arg"`
+	Valid    bool             `json:"valid"`
+	Icon     scriptFilterIcon `json:"icon"`
+}
+
+type scriptFilterIcon struct {
+	Path string `json:"path"`
+}
+
+// ScriptFilterString formats items for launchers. The arg is the item id,
+// and the icon is `icons/pN.png` relative to the workflow by priority.
+func ScriptFilterString(items []todoist.Item, relations todoist.ItemRelations) (string, error) {
+	res := struct {
+		Items []scriptFilterItem `json:"items"`
+	}{Items: []scriptFilterItem{}}
+	for _, i := range items {
+		var subtitle []string
+		if p, ok := relations.Projects[i.ProjectID]; ok {
+			subtitle = append(subtitle, p.String())
+		}
+		for _, lid := range i.Labels {
+			if l, ok := relations.Labels[lid]; ok {
+				subtitle = append(subtitle, l.String())
+			}
+		}
+		if !i.Due.Date.IsZero() {
+			subtitle = append(subtitle, i.Due.Date.String())
+		}
+		res.Items = append(res.Items, scriptFilterItem{
+			UID:      i.ID.String(),
+			Title:    i.Content,
+			Subtitle: strings.Join(subtitle, " "),
+			Arg:      i.ID.String(),
+			Valid:    true,
+			Icon:     scriptFilterIcon{Path: "icons/p" + strconv.Itoa(5-priority(i)) + ".png"},
+		})
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
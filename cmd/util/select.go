@@ -0,0 +1,85 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"strconv"
+	"strings"
+)
+
+// ErrAbort is returned when the user quits a prompt.
+var ErrAbort = errors.New("abort")
+
+type checkbox bool
+
+func (c checkbox) String() string {
+	if c {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+func (c checkbox) ColorString() string {
+	return c.String()
+}
+
+// SelectItems shows the items as a checklist, toggles the numbers answered
+// until an empty answer, and returns the checked items.
+func SelectItems(items []todoist.Item, relations todoist.ItemRelations) ([]todoist.Item, error) {
+	selected := make([]bool, len(items))
+	rows := itemRows(items, relations, func(i todoist.Item) todoist.Time { return i.Due.Date })
+	for {
+		var table [][]todoist.ColorStringer
+		for i, row := range rows {
+			n := todoist.NewNoColorString(strconv.Itoa(i + 1))
+			table = append(table, append([]todoist.ColorStringer{checkbox(selected[i]), n}, row[1:]...))
+		}
+		fmt.Println(TableString(table))
+		ans, ok := Prompt("toggle items (e.g. 1 3-5, a: all, n: none, q: quit, empty: done)")
+		if !ok || ans == "q" {
+			return nil, ErrAbort
+		}
+		if len(strings.TrimSpace(ans)) == 0 {
+			break
+		}
+		if err := toggleSelection(selected, ans); err != nil {
+			fmt.Println(err)
+		}
+	}
+	var res []todoist.Item
+	for i, item := range items {
+		if selected[i] {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+func toggleSelection(selected []bool, ans string) error {
+	for _, field := range strings.FieldsFunc(ans, func(r rune) bool { return r == ' ' || r == ',' }) {
+		switch field {
+		case "a", "n":
+			for i := range selected {
+				selected[i] = field == "a"
+			}
+			continue
+		}
+		from, to := field, field
+		if i := strings.Index(field, "-"); i > 0 {
+			from, to = field[:i], field[i+1:]
+		}
+		start, err := strconv.Atoi(from)
+		if err != nil {
+			return fmt.Errorf("invalid number: %s", field)
+		}
+		end, err := strconv.Atoi(to)
+		if err != nil || start < 1 || end > len(selected) || start > end {
+			return fmt.Errorf("invalid number: %s", field)
+		}
+		for i := start - 1; i < end; i++ {
+			selected[i] = !selected[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ParseSince parses a relative period such as `10d`, `2w`, `3m` or `1y`,
+// or a date like `2019-03-10`, into the time it starts from.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if len(s) < 2 {
+		return time.Time{}, fmt.Errorf("invalid period: %s", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return time.Time{}, fmt.Errorf("invalid period: %s", s)
+	}
+	switch s[len(s)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -n), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*n), nil
+	case 'm':
+		return now.AddDate(0, -n, 0), nil
+	case 'y':
+		return now.AddDate(-n, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid period: %s", s)
+}
+
+// LabelStat is how many items with the label were completed, and how long they took.
+type LabelStat struct {
+	Label     todoist.Label
+	Completed int
+	// days from added to completed, for the items whose added date is known
+	days  float64
+	dated int
+}
+
+func (s LabelStat) AverageDays() float64 {
+	if s.dated == 0 {
+		return 0
+	}
+	return s.days / float64(s.dated)
+}
+
+// NewLabelStats counts the completed items per label. A completed item is
+// joined to its labels and added date through items, keyed by the task id.
+// The result is sorted by the number of completed items.
+func NewLabelStats(completed []todoist.Item, items map[todoist.ID]todoist.Item, labels []todoist.Label) []LabelStat {
+	stats := map[todoist.ID]*LabelStat{}
+	for _, l := range labels {
+		stats[l.ID] = &LabelStat{Label: l}
+	}
+	for _, c := range completed {
+		item, ok := items[c.TaskID]
+		if !ok {
+			continue
+		}
+		for _, id := range item.Labels {
+			stat, ok := stats[id]
+			if !ok {
+				continue
+			}
+			stat.Completed++
+			if !item.DateAdded.IsZero() && !c.CompletedDate.IsZero() {
+				stat.days += c.CompletedDate.Sub(item.DateAdded.Time).Hours() / 24
+				stat.dated++
+			}
+		}
+	}
+	var res []LabelStat
+	for _, s := range stats {
+		res = append(res, *s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Completed != res[j].Completed {
+			return res[i].Completed > res[j].Completed
+		}
+		return res[i].Label.Name < res[j].Label.Name
+	})
+	return res
+}
+
+func LabelStatTableString(stats []LabelStat) string {
+	var rows [][]todoist.ColorStringer
+	for _, s := range stats {
+		avg := "-"
+		if s.dated > 0 {
+			avg = fmt.Sprintf("%.1f", s.AverageDays())
+		}
+		rows = append(rows, []todoist.ColorStringer{
+			s.Label,
+			todoist.NewNoColorString(strconv.Itoa(s.Completed)),
+			todoist.NewNoColorString(avg),
+		})
+	}
+	return TableString(rows)
+}
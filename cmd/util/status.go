@@ -0,0 +1,97 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Status summarizes the tasks due by today for status bars.
+type Status struct {
+	Due     int
+	Overdue int
+	Next    []todoist.Item
+}
+
+func NewStatus(items []todoist.Item, next int) Status {
+	var s Status
+	var dated []todoist.Item
+	today := todoist.Today()
+	for _, i := range items {
+		if i.IsChecked() || i.Due.Date.IsZero() {
+			continue
+		}
+		dated = append(dated, i)
+		if i.Due.Date.Local().Before(today) {
+			s.Due++
+			if i.IsOverDueDate() {
+				s.Overdue++
+			}
+		}
+	}
+	sort.Slice(dated, func(i, j int) bool {
+		return dated[i].Due.Date.Local().Before(dated[j].Due.Date.Local())
+	})
+	if len(dated) > next {
+		dated = dated[:next]
+	}
+	s.Next = dated
+	return s
+}
+
+func (s Status) tooltip() string {
+	var lines []string
+	for _, i := range s.Next {
+		lines = append(lines, i.Due.Date.String()+" "+i.Content)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s Status) String() string {
+	res := fmt.Sprintf("%d tasks due today (%d overdue)", s.Due, s.Overdue)
+	if t := s.tooltip(); len(t) != 0 {
+		res += "\n" + t
+	}
+	return res
+}
+
+// WaybarString is the json of a waybar custom module.
+func (s Status) WaybarString() (string, error) {
+	class := "normal"
+	if s.Overdue > 0 {
+		class = "overdue"
+	}
+	b, err := json.Marshal(map[string]string{
+		"text":    strconv.Itoa(s.Due),
+		"tooltip": s.tooltip(),
+		"class":   class,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// I3blocksString is the full text, short text and color lines of an i3blocks blocklet.
+// The blocklet should exit with 33 to be urgent when Urgent reports true.
+func (s Status) I3blocksString() string {
+	color := ""
+	if s.Overdue > 0 {
+		color = "#FF0000"
+	}
+	return fmt.Sprintf("todoist %d\n%d\n%s", s.Due, s.Due, color)
+}
+
+func (s Status) TmuxString() string {
+	if s.Overdue > 0 {
+		return fmt.Sprintf("#[fg=red]%d#[default]", s.Due)
+	}
+	return strconv.Itoa(s.Due)
+}
+
+func (s Status) Urgent() bool {
+	return s.Overdue > 0
+}
@@ -0,0 +1,155 @@
+package util
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/kobtea/go-todoist/todoist"
+	"os"
+)
+
+// stateDir is where the CLI keeps local state beside the config and the sync cache.
+func stateDir() (string, error) {
+	dir := todoist.DefaultDir()
+	if _, err := os.Stat(dir); err != nil {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// StorageConfig places the local state and the pending commands in a database
+// instead of the files of the config directory.
+type StorageConfig struct {
+	// Driver is the database/sql driver, such as sqlite3 of a build with `-tags sqlite`.
+	// It has to take ? placeholders, see todoist.SQLStore.
+	Driver string `json:"driver"`
+	DSN    string `json:"dsn"`
+	// Table defaults to todoist_store.
+	Table string `json:"table,omitempty"`
+}
+
+// Storage is the storage of the config.
+var Storage StorageConfig
+
+var store todoist.Store
+
+// OpenStore returns the store of the local state, the files of the config directory
+// unless the storage of the config names a driver.
+func OpenStore() (todoist.Store, error) {
+	if store != nil {
+		return store, nil
+	}
+	if len(Storage.Driver) == 0 {
+		dir, err := stateDir()
+		if err != nil {
+			return nil, err
+		}
+		store = todoist.FileStore{Dir: dir}
+		return store, nil
+	}
+	db, err := sql.Open(Storage.Driver, Storage.DSN)
+	if err != nil {
+		return nil, err
+	}
+	s, err := todoist.NewSQLStore(db, Storage.Table)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	store = s
+	return store, nil
+}
+
+// ReadState decodes the local state stored under the name into v.
+// It reports os.IsNotExist errors when nothing has been stored yet.
+func ReadState(name string, v interface{}) error {
+	s, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	b, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// WriteState stores v as the local state under the name.
+func WriteState(name string, v interface{}) error {
+	s, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.Put(name, b)
+}
+
+// DeleteState removes the local state stored under the name.
+func DeleteState(name string) error {
+	s, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	return s.Delete(name)
+}
+
+// ItemPosition is the placement of an item, which completion may discard.
+type ItemPosition struct {
+	ProjectID  todoist.ID `json:"project_id"`
+	SectionID  todoist.ID `json:"section_id"`
+	ParentID   todoist.ID `json:"parent_id"`
+	ChildOrder int        `json:"child_order"`
+}
+
+const positionsState = "positions"
+
+// SavePosition remembers the placement of the item to restore it on uncompletion.
+func SavePosition(item todoist.Item) error {
+	positions := map[todoist.ID]ItemPosition{}
+	if err := ReadState(positionsState, &positions); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	positions[item.ID] = ItemPosition{
+		ProjectID:  item.ProjectID,
+		SectionID:  item.SectionID,
+		ParentID:   item.ParentID,
+		ChildOrder: item.ChildOrder,
+	}
+	return WriteState(positionsState, positions)
+}
+
+// ReadPosition returns the remembered placement of the item, or nil without one.
+func ReadPosition(id todoist.ID) (*ItemPosition, error) {
+	positions := map[todoist.ID]ItemPosition{}
+	if err := ReadState(positionsState, &positions); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	position, ok := positions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &position, nil
+}
+
+// ForgetPosition forgets the remembered placement of the item, once it is restored.
+func ForgetPosition(id todoist.ID) error {
+	positions := map[todoist.ID]ItemPosition{}
+	if err := ReadState(positionsState, &positions); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if _, ok := positions[id]; !ok {
+		return nil
+	}
+	delete(positions, id)
+	return WriteState(positionsState, positions)
+}
@@ -0,0 +1,7 @@
+//go:build sqlite
+// +build sqlite
+
+package util
+
+// the sqlite3 driver for the storage of the config, which cgo requires
+import _ "github.com/mattn/go-sqlite3"
@@ -0,0 +1,214 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// Template is a reusable scaffold of a project, shared as yaml:
+//
+//	name: Release
+//	sections:
+//	- name: Prepare
+//	  items:
+//	  - content: Write the changelog
+//	    priority: 3
+//	    labels: [deep]
+//	    due: tomorrow
+//	    items:
+//	    - content: Collect the merged changes
+//	items:
+//	- content: Announce the release
+type Template struct {
+	Name     string            `yaml:"name"`
+	Color    int               `yaml:"color,omitempty"`
+	Sections []TemplateSection `yaml:"sections,omitempty"`
+	Items    []TemplateItem    `yaml:"items,omitempty"`
+}
+
+type TemplateSection struct {
+	Name  string         `yaml:"name"`
+	Items []TemplateItem `yaml:"items,omitempty"`
+}
+
+// TemplateItem is an item of a template, whose items are the sub items.
+type TemplateItem struct {
+	Content  string         `yaml:"content"`
+	Priority int            `yaml:"priority,omitempty"`
+	Labels   []string       `yaml:"labels,omitempty"`
+	Due      string         `yaml:"due,omitempty"`
+	Items    []TemplateItem `yaml:"items,omitempty"`
+}
+
+func ParseTemplate(b []byte) (*Template, error) {
+	var t Template
+	if err := yaml.UnmarshalStrict(b, &t); err != nil {
+		return nil, err
+	}
+	if len(t.Name) == 0 {
+		return nil, errors.New("template requires a name")
+	}
+	var check func(items []TemplateItem) error
+	check = func(items []TemplateItem) error {
+		for _, i := range items {
+			if len(i.Content) == 0 {
+				return errors.New("item of template requires a content")
+			}
+			if i.Priority < 0 || i.Priority > 4 {
+				return fmt.Errorf("invalid priority of %s: %d", i.Content, i.Priority)
+			}
+			if err := check(i.Items); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, s := range t.Sections {
+		if len(s.Name) == 0 {
+			return nil, errors.New("section of template requires a name")
+		}
+		if err := check(s.Items); err != nil {
+			return nil, err
+		}
+	}
+	if err := check(t.Items); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (t Template) YAML() ([]byte, error) {
+	return yaml.Marshal(t)
+}
+
+// NewTemplate makes a template of the unchecked items and the sections of the project.
+// Due dates are kept only when they recur, as others would be stale when applied.
+func NewTemplate(client *todoist.Client, projectID todoist.ID) (*Template, error) {
+	project := client.Project.Resolve(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("no such project id: %s", projectID)
+	}
+	var items []todoist.Item
+	for _, i := range client.Item.FindByProjectIDs([]todoist.ID{projectID}) {
+		if !i.IsChecked() {
+			items = append(items, i)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ChildOrder < items[j].ChildOrder })
+	var children func(parentID, sectionID todoist.ID) []TemplateItem
+	children = func(parentID, sectionID todoist.ID) []TemplateItem {
+		var res []TemplateItem
+		for _, i := range items {
+			if i.ParentID != parentID || (parentID.IsZero() && i.SectionID != sectionID) {
+				continue
+			}
+			ti := TemplateItem{Content: i.Content, Priority: i.Priority, Items: children(i.ID, sectionID)}
+			if ti.Priority == 1 {
+				ti.Priority = 0
+			}
+			for _, id := range i.Labels {
+				if l := client.Label.Resolve(id); l != nil {
+					ti.Labels = append(ti.Labels, l.Name)
+				}
+			}
+			if i.Due.IsRecurring {
+				ti.Due = i.Due.String
+			}
+			res = append(res, ti)
+		}
+		return res
+	}
+	t := Template{Name: project.Name, Color: project.Color, Items: children("", "")}
+	sections := client.Section.FindByProjectID(projectID)
+	sort.Slice(sections, func(i, j int) bool { return sections[i].SectionOrder < sections[j].SectionOrder })
+	for _, s := range sections {
+		t.Sections = append(t.Sections, TemplateSection{Name: s.Name, Items: children("", s.ID)})
+	}
+	return &t, nil
+}
+
+// Queue adds a project of the template named name, or the name of the template when it is empty.
+// Labels of the template which do not exist are added as well.
+func (t Template) Queue(client *todoist.Client, name string) (*todoist.Project, error) {
+	if len(name) == 0 {
+		name = t.Name
+	}
+	project, err := todoist.NewProject(name, &todoist.NewProjectOpts{Color: t.Color})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = client.Project.Add(*project); err != nil {
+		return nil, err
+	}
+	labels := map[string]todoist.ID{}
+	labelID := func(name string) (todoist.ID, error) {
+		if id, ok := labels[name]; ok {
+			return id, nil
+		}
+		if l := client.Label.FindOneByName(name); l != nil && l.Name == name {
+			labels[name] = l.ID
+			return l.ID, nil
+		}
+		l, err := todoist.NewLabel(name, &todoist.NewLabelOpts{})
+		if err != nil {
+			return "", err
+		}
+		if _, err = client.Label.Add(*l); err != nil {
+			return "", err
+		}
+		labels[name] = l.ID
+		return l.ID, nil
+	}
+	var add func(items []TemplateItem, sectionID, parentID todoist.ID) error
+	add = func(items []TemplateItem, sectionID, parentID todoist.ID) error {
+		for n, ti := range items {
+			item := todoist.Item{
+				ProjectID:  project.ID,
+				SectionID:  sectionID,
+				ParentID:   parentID,
+				Content:    ti.Content,
+				Priority:   ti.Priority,
+				ChildOrder: n + 1,
+			}
+			if len(ti.Due) != 0 {
+				if err := client.Item.SetDue(&item, ti.Due); err != nil {
+					return err
+				}
+			}
+			for _, l := range ti.Labels {
+				id, err := labelID(l)
+				if err != nil {
+					return err
+				}
+				item.Labels = append(item.Labels, id)
+			}
+			added, err := client.Item.Add(item)
+			if err != nil {
+				return err
+			}
+			if err = add(ti.Items, sectionID, added.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err = add(t.Items, "", ""); err != nil {
+		return nil, err
+	}
+	for _, s := range t.Sections {
+		section, err := todoist.NewSection(s.Name, project.ID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = client.Section.Add(*section); err != nil {
+			return nil, err
+		}
+		if err = add(s.Items, section.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+	return project, nil
+}
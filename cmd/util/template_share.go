@@ -0,0 +1,115 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const gistAPI = "https://api.github.com/gists"
+
+// PublishTemplate uploads the yaml of a template to the backend of the config
+// and returns the url to fetch it.
+func PublishTemplate(c TemplateConfig, name string, b []byte) (string, error) {
+	switch c.Backend {
+	case "gist":
+		body, err := json.Marshal(map[string]interface{}{
+			"description": "todoist template: " + name,
+			"public":      false,
+			"files":       map[string]interface{}{name + ".yaml": map[string]string{"content": string(b)}},
+		})
+		if err != nil {
+			return "", err
+		}
+		req, err := http.NewRequest(http.MethodPost, gistAPI, bytes.NewReader(body))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "token "+c.Token)
+		var out struct {
+			HTMLURL string `json:"html_url"`
+		}
+		if err = doJSON(req, &out); err != nil {
+			return "", err
+		}
+		return out.HTMLURL, nil
+	case "paste":
+		if len(c.URL) == 0 {
+			return "", errors.New("require the url of the paste backend")
+		}
+		res, err := http.Post(c.URL, "text/yaml", bytes.NewReader(b))
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+		out, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return "", err
+		}
+		if (res.StatusCode / 100) != 2 {
+			return "", fmt.Errorf("failed to publish the template, status code: %d", res.StatusCode)
+		}
+		// the paste backend answers the url of the paste
+		return strings.TrimSpace(string(out)), nil
+	}
+	return "", errors.New("configure the template backend, gist or paste, in the config")
+}
+
+// FetchTemplate downloads the yaml of a template at the url. A gist url is read through the api.
+func FetchTemplate(c TemplateConfig, rawurl string) ([]byte, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "gist.github.com" {
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		req, err := http.NewRequest(http.MethodGet, gistAPI+"/"+parts[len(parts)-1], nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(c.Token) != 0 {
+			req.Header.Set("Authorization", "token "+c.Token)
+		}
+		var out struct {
+			Files map[string]struct {
+				Content string `json:"content"`
+			} `json:"files"`
+		}
+		if err = doJSON(req, &out); err != nil {
+			return nil, err
+		}
+		for name, f := range out.Files {
+			if strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml") {
+				return []byte(f.Content), nil
+			}
+		}
+		return nil, errors.New("no yaml file in the gist")
+	}
+	res, err := http.Get(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if (res.StatusCode / 100) != 2 {
+		return nil, fmt.Errorf("failed to fetch the template, status code: %d", res.StatusCode)
+	}
+	return ioutil.ReadAll(res.Body)
+}
+
+func doJSON(req *http.Request, out interface{}) error {
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if (res.StatusCode / 100) != 2 {
+		return fmt.Errorf("failed to request %s, status code: %d", req.URL, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,201 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeBlock is an item scheduled from Start until End.
+type TimeBlock struct {
+	Item  todoist.Item `json:"item"`
+	Start time.Time    `json:"start"`
+	End   time.Time    `json:"end"`
+	// Fixed reports whether the start is the due time of the item, not a slot chosen by the schedule.
+	Fixed bool `json:"fixed"`
+	// Conflict reports whether the block overlaps the former block.
+	Conflict bool `json:"conflict"`
+}
+
+// TimeGap is a free time between blocks.
+type TimeGap struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Schedule is the time blocks of a day.
+type Schedule struct {
+	Date   time.Time   `json:"date"`
+	Blocks []TimeBlock `json:"blocks"`
+	Gaps   []TimeGap   `json:"gaps"`
+}
+
+// NewSchedule schedules the items due on the day of date. Items with a due time start at the time
+// and last their duration, or def without a duration. Items of the day without a time but with
+// a duration fill the first gap long enough from start, in the order of their priority.
+// Blocks overlapping the former one are conflicts.
+func NewSchedule(items []todoist.Item, date time.Time, start time.Time, def time.Duration) Schedule {
+	s := Schedule{Date: date, Blocks: []TimeBlock{}, Gaps: []TimeGap{}}
+	y, m, d := date.Date()
+	type slot struct {
+		item   todoist.Item
+		length time.Duration
+	}
+	var floating []slot
+	for _, i := range items {
+		if i.IsChecked() || i.Due.Date.IsZero() {
+			continue
+		}
+		due := i.Due.Date.Local()
+		if dy, dm, dd := due.Date(); dy != y || dm != m || dd != d {
+			continue
+		}
+		length := def
+		if i.Duration != nil && i.Duration.Duration() > 0 {
+			length = i.Duration.Duration()
+		}
+		if i.Due.Date.DateOnly() {
+			if i.Duration != nil && i.Duration.Duration() > 0 {
+				floating = append(floating, slot{i, length})
+			}
+			continue
+		}
+		s.Blocks = append(s.Blocks, TimeBlock{Item: i, Start: due.Time, End: due.Add(length), Fixed: true})
+	}
+	sort.SliceStable(s.Blocks, func(i, j int) bool { return s.Blocks[i].Start.Before(s.Blocks[j].Start) })
+	sort.SliceStable(floating, func(i, j int) bool { return floating[i].item.Priority > floating[j].item.Priority })
+	for _, f := range floating {
+		at := start
+		n := 0
+		// the first gap long enough, or after the last block
+		for ; n < len(s.Blocks); n++ {
+			if !at.Add(f.length).After(s.Blocks[n].Start) {
+				break
+			}
+			if s.Blocks[n].End.After(at) {
+				at = s.Blocks[n].End
+			}
+		}
+		b := TimeBlock{Item: f.item, Start: at, End: at.Add(f.length)}
+		s.Blocks = append(s.Blocks[:n], append([]TimeBlock{b}, s.Blocks[n:]...)...)
+	}
+	var end time.Time
+	for n := range s.Blocks {
+		b := &s.Blocks[n]
+		if n > 0 && b.Start.Before(end) {
+			b.Conflict = true
+		} else if n > 0 && b.Start.After(end) {
+			s.Gaps = append(s.Gaps, TimeGap{Start: end, End: b.Start})
+		}
+		if b.End.After(end) {
+			end = b.End
+		}
+	}
+	return s
+}
+
+func formatClock(t time.Time) string {
+	return t.Local().Format("15:04")
+}
+
+func (b TimeBlock) note() string {
+	switch {
+	case b.Conflict:
+		return "conflict"
+	case !b.Fixed:
+		return "slotted"
+	}
+	return ""
+}
+
+// ScheduleOutput has a row for each block and gap.
+func ScheduleOutput(s Schedule, relations todoist.ItemRelations) Output {
+	type row struct {
+		start time.Time
+		cells []todoist.ColorStringer
+	}
+	var rows []row
+	for _, b := range s.Blocks {
+		rows = append(rows, row{b.Start, []todoist.ColorStringer{
+			todoist.NewNoColorString(formatClock(b.Start) + "-" + formatClock(b.End)),
+			todoist.NewNoColorString(FormatDuration(b.End.Sub(b.Start))),
+			relations.Projects[b.Item.ProjectID],
+			todoist.NewNoColorString(b.Item.Content),
+			todoist.NewNoColorString(b.note()),
+		}})
+	}
+	for _, g := range s.Gaps {
+		rows = append(rows, row{g.Start, []todoist.ColorStringer{
+			todoist.NewNoColorString(formatClock(g.Start) + "-" + formatClock(g.End)),
+			todoist.NewNoColorString(FormatDuration(g.End.Sub(g.Start))),
+			todoist.NewNoColorString(""),
+			todoist.NewNoColorString("(free)"),
+			todoist.NewNoColorString("gap"),
+		}})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].start.Before(rows[j].start) })
+	var cells [][]todoist.ColorStringer
+	for _, r := range rows {
+		cells = append(cells, r.cells)
+	}
+	return Output{
+		Columns: []string{"time", "duration", "project", "content", "note"},
+		Rows:    cells,
+		Data:    s,
+	}
+}
+
+// FormatDuration formats d like 1h30m or 45m.
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// Markdown returns the schedule as a markdown list.
+func (s Schedule) Markdown() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "## %s\n\n", s.Date.Format("Mon, Jan 2 2006"))
+	gaps := s.Gaps
+	for _, b := range s.Blocks {
+		for len(gaps) > 0 && !gaps[0].Start.After(b.Start) {
+			fmt.Fprintf(&buf, "- %s-%s _free_\n", formatClock(gaps[0].Start), formatClock(gaps[0].End))
+			gaps = gaps[1:]
+		}
+		line := fmt.Sprintf("- %s-%s %s", formatClock(b.Start), formatClock(b.End), b.Item.Content)
+		if note := b.note(); len(note) != 0 {
+			line += " **(" + note + ")**"
+		}
+		fmt.Fprintln(&buf, line)
+	}
+	return buf.String()
+}
+
+// ICS returns the blocks as the events of an iCalendar.
+func (s Schedule) ICS() string {
+	const layout = "20060102T150405Z"
+	escape := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//go-todoist//timeblocks//EN"}
+	stamp := time.Now().UTC().Format(layout)
+	for _, b := range s.Blocks {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%s-%s@go-todoist", b.Item.ID, b.Start.UTC().Format(layout)),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+b.Start.UTC().Format(layout),
+			"DTEND:"+b.End.UTC().Format(layout),
+			"SUMMARY:"+escape.Replace(b.Item.Content),
+			"END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
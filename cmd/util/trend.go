@@ -0,0 +1,110 @@
+package util
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"math"
+	"strconv"
+	"time"
+)
+
+// TrendWeek is the number of items created and completed in the week from Start, a monday.
+type TrendWeek struct {
+	Start     time.Time `json:"start"`
+	Created   int       `json:"created"`
+	Completed int       `json:"completed"`
+}
+
+// Net is the growth of the backlog in the week.
+func (w TrendWeek) Net() int {
+	return w.Created - w.Completed
+}
+
+// Trend is the weekly growth of the backlog and its forecast.
+type Trend struct {
+	Weeks []TrendWeek `json:"weeks"`
+	// Backlog is the number of the unchecked items now.
+	Backlog int `json:"backlog"`
+	// AverageNet is the average growth of the full weeks, which the forecast extrapolates.
+	AverageNet float64 `json:"average_net"`
+	// ForecastWeeks later, the backlog is expected to be Forecast.
+	ForecastWeeks int `json:"forecast_weeks"`
+	Forecast      int `json:"forecast"`
+}
+
+func weekStart(t time.Time) time.Time {
+	t = t.Local()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// NewTrend counts the added and completed events of items for the weeks up to the current one.
+func NewTrend(events []todoist.Event, weeks int, now time.Time, backlog, forecastWeeks int) Trend {
+	t := Trend{Backlog: backlog, ForecastWeeks: forecastWeeks}
+	first := weekStart(now).AddDate(0, 0, -7*(weeks-1))
+	for n := 0; n < weeks; n++ {
+		t.Weeks = append(t.Weeks, TrendWeek{Start: first.AddDate(0, 0, 7*n)})
+	}
+	for _, e := range events {
+		if e.ObjectType != "item" || e.EventDate.Time.Before(first) {
+			continue
+		}
+		n := int(weekStart(e.EventDate.Time).Sub(first).Hours()+12) / (7 * 24)
+		if n >= weeks {
+			continue
+		}
+		switch e.EventType {
+		case "added":
+			t.Weeks[n].Created++
+		case "completed":
+			t.Weeks[n].Completed++
+		}
+	}
+	// the current week is not over, so it is left out of the average unless it is the only one
+	full := t.Weeks
+	if len(full) > 1 {
+		full = full[:len(full)-1]
+	}
+	sum := 0
+	for _, w := range full {
+		sum += w.Net()
+	}
+	if len(full) > 0 {
+		t.AverageNet = float64(sum) / float64(len(full))
+	}
+	t.Forecast = backlog + int(math.Round(t.AverageNet*float64(forecastWeeks)))
+	if t.Forecast < 0 {
+		t.Forecast = 0
+	}
+	return t
+}
+
+// String returns a summary like `backlog 42, +1.5 a week, 48 in 4 weeks: overcommitting`.
+func (t Trend) String() string {
+	verdict := "steady"
+	switch {
+	case t.AverageNet >= 0.5:
+		verdict = "overcommitting"
+	case t.AverageNet <= -0.5:
+		verdict = "catching up"
+	}
+	return fmt.Sprintf("backlog %d, %+.1f a week, %d in %d weeks: %s", t.Backlog, t.AverageNet, t.Forecast, t.ForecastWeeks, verdict)
+}
+
+// TrendOutput has a row for each week.
+func TrendOutput(t Trend) Output {
+	var rows [][]todoist.ColorStringer
+	for _, w := range t.Weeks {
+		rows = append(rows, []todoist.ColorStringer{
+			todoist.NewNoColorString(w.Start.Format("2006-01-02")),
+			todoist.NewNoColorString(strconv.Itoa(w.Created)),
+			todoist.NewNoColorString(strconv.Itoa(w.Completed)),
+			todoist.NewNoColorString(fmt.Sprintf("%+d", w.Net())),
+		})
+	}
+	return Output{
+		Columns: []string{"week", "created", "completed", "net"},
+		Rows:    rows,
+		Data:    t,
+	}
+}
@@ -0,0 +1,156 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/fatih/color"
+	"github.com/kobtea/go-todoist/todoist"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ViewCacheConfig keeps the rendered output of views, such as `today`, to print it again
+// while the sync token and the arguments are the same.
+type ViewCacheConfig struct {
+	// TTL bounds the reuse of a view, which depends on the time as well, such as `1m`.
+	// 0 disables the cache.
+	TTL string `json:"ttl,omitempty"`
+}
+
+const defaultViewTTL = time.Minute
+
+func (c ViewCacheConfig) ttl() (time.Duration, error) {
+	if len(c.TTL) == 0 {
+		return defaultViewTTL, nil
+	}
+	return time.ParseDuration(c.TTL)
+}
+
+// ViewCache is the view cache of the config.
+var ViewCache ViewCacheConfig
+
+// NoViewCache renders the views without the cache, set by the global `--no-view-cache` flag.
+var NoViewCache bool
+
+// View is the output of a view as printed.
+type View struct {
+	At     time.Time `json:"at"`
+	Stdout []byte    `json:"stdout"`
+	Stderr []byte    `json:"stderr"`
+}
+
+const viewsState = "views"
+
+// viewsLimit is the number of views kept, the latest ones.
+const viewsLimit = 20
+
+// viewKey addresses the output of a view by what it is rendered from: the sync token of the
+// cache, the parts of the command and the environment of the rendering.
+func viewKey(client *todoist.Client, parts []string) string {
+	focus := ""
+	if f, _ := LoadFocus(); f != nil && !NoFocus {
+		focus = f.Query + "\x00" + f.Until.String()
+	}
+	env := []string{
+		client.SyncToken,
+		OutputFormat,
+		strconv.FormatBool(color.NoColor),
+		time.Local.String(),
+		time.Now().Format("2006-01-02"),
+		focus,
+		StaleNote(),
+	}
+	h := sha256.Sum256([]byte(strings.Join(append(env, parts...), "\x00")))
+	return hex.EncodeToString(h[:])
+}
+
+// CachedView prints the view rendered from the same cache and parts within the ttl,
+// or runs render and keeps what it prints. A view failed to render is not kept.
+func CachedView(client *todoist.Client, parts []string, render func() error) error {
+	ttl, err := ViewCache.ttl()
+	if err != nil {
+		return err
+	}
+	// without a cache, there is no sync token to address the views
+	if NoViewCache || ttl <= 0 || client.SyncToken == "*" {
+		return render()
+	}
+	key := viewKey(client, parts)
+	views := map[string]View{}
+	if err := ReadState(viewsState, &views); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if v, ok := views[key]; ok && time.Since(v.At) < ttl {
+		os.Stdout.Write(v.Stdout)
+		os.Stderr.Write(v.Stderr)
+		return nil
+	}
+	stdout, stderr, err := capture(render)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for k, v := range views {
+		if now.Sub(v.At) >= ttl {
+			delete(views, k)
+		}
+	}
+	views[key] = View{At: now, Stdout: stdout, Stderr: stderr}
+	if len(views) > viewsLimit {
+		var keys []string
+		for k := range views {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return views[keys[i]].At.Before(views[keys[j]].At)
+		})
+		for _, k := range keys[:len(keys)-viewsLimit] {
+			delete(views, k)
+		}
+	}
+	return WriteState(viewsState, views)
+}
+
+// capture runs f while copying what it prints to stdout and stderr.
+func capture(f func() error) ([]byte, []byte, error) {
+	var stdout, stderr bytes.Buffer
+	restoreStdout, err := tee(&os.Stdout, &stdout)
+	if err != nil {
+		return nil, nil, err
+	}
+	restoreStderr, err := tee(&os.Stderr, &stderr)
+	if err != nil {
+		restoreStdout()
+		return nil, nil, err
+	}
+	err = f()
+	restoreStderr()
+	restoreStdout()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// tee replaces the file with a pipe writing to both the file and buf, until the returned restore.
+func tee(f **os.File, buf *bytes.Buffer) (func(), error) {
+	orig := *f
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	*f = w
+	done := make(chan struct{})
+	go func() {
+		io.Copy(io.MultiWriter(orig, buf), r)
+		close(done)
+	}()
+	return func() {
+		w.Close()
+		<-done
+		r.Close()
+		*f = orig
+	}, nil
+}
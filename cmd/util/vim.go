@@ -0,0 +1,77 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const itemURIPrefix = "todoist://item/"
+
+// VimgrepString formats items as `file:line:col:text` lines, which vim reads as a quickfix list.
+// The file part is `todoist://item/ID` so that entries can be mapped back to items.
+func VimgrepString(items []todoist.Item, relations todoist.ItemRelations) string {
+	var lines []string
+	for _, i := range items {
+		text := []string{"p" + strconv.Itoa(5-priority(i)), i.Content}
+		if p, ok := relations.Projects[i.ProjectID]; ok {
+			text = append(text, p.String())
+		}
+		for _, lid := range i.Labels {
+			if l, ok := relations.Labels[lid]; ok {
+				text = append(text, l.String())
+			}
+		}
+		if !i.Due.Date.IsZero() {
+			text = append(text, "due:"+i.Due.Date.String())
+		}
+		lines = append(lines, fmt.Sprintf("%s%s:1:1:%s", itemURIPrefix, i.ID, strings.Join(text, " ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func priority(i todoist.Item) int {
+	if i.Priority == 0 {
+		return 1
+	}
+	return i.Priority
+}
+
+// quickfixLines match the lines of VimgrepString, `file:line:col:text`, and the lines of the quickfix window
+// which vim writes by :w, `file|line col N| text`.
+var quickfixLines = []*regexp.Regexp{
+	regexp.MustCompile(`^(.+?):\d+:(?:\d+:)?`),
+	regexp.MustCompile(`^(.+?)\|\d+(?: col \d+)?\|`),
+}
+
+// ParseQuickfix returns the item ids of quickfix lines written by VimgrepString or by :w of the quickfix window.
+// Lines which do not refer to an item are skipped.
+func ParseQuickfix(r io.Reader) ([]todoist.ID, error) {
+	var ids []todoist.ID
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		var m []string
+		for _, re := range quickfixLines {
+			if m = re.FindStringSubmatch(line); m != nil && strings.HasPrefix(m[1], itemURIPrefix) {
+				break
+			}
+		}
+		if m == nil || !strings.HasPrefix(m[1], itemURIPrefix) {
+			continue
+		}
+		id, err := todoist.NewID(strings.TrimPrefix(m[1], itemURIPrefix))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
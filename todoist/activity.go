@@ -0,0 +1,123 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Event is an entry of the activity log, such as the completion of an item.
+type Event struct {
+	ID              ID                     `json:"id"`
+	ObjectType      string                 `json:"object_type"`
+	ObjectID        ID                     `json:"object_id"`
+	EventType       string                 `json:"event_type"`
+	EventDate       Time                   `json:"event_date"`
+	ParentProjectID ID                     `json:"parent_project_id"`
+	ParentItemID    ID                     `json:"parent_item_id"`
+	InitiatorID     ID                     `json:"initiator_id"`
+	ExtraData       map[string]interface{} `json:"extra_data"`
+}
+
+type ActivityEvents struct {
+	Events []Event `json:"events"`
+	Count  int     `json:"count"`
+}
+
+type ActivityClient struct {
+	*Client
+}
+
+// ActivityGetOpts narrows the events returned by Get.
+// Zero values are not sent to the server.
+type ActivityGetOpts struct {
+	ObjectType      string
+	ObjectID        ID
+	EventType       string
+	ParentProjectID ID
+	Since           Time
+	Until           Time
+	// Limit is the number of events per request, up to 100.
+	Limit  int
+	Offset int
+}
+
+func (c *ActivityClient) Get(ctx context.Context, opts *ActivityGetOpts) (*ActivityEvents, error) {
+	const layout = "2006-01-02T15:04"
+	values := url.Values{}
+	if len(opts.ObjectType) != 0 {
+		values.Add("object_type", opts.ObjectType)
+	}
+	if !opts.ObjectID.IsZero() {
+		values.Add("object_id", opts.ObjectID.String())
+	}
+	if len(opts.EventType) != 0 {
+		values.Add("event_type", opts.EventType)
+	}
+	if !opts.ParentProjectID.IsZero() {
+		values.Add("parent_project_id", opts.ParentProjectID.String())
+	}
+	if !opts.Since.IsZero() {
+		values.Add("since", opts.Since.UTC().Format(layout))
+	}
+	if !opts.Until.IsZero() {
+		values.Add("until", opts.Until.UTC().Format(layout))
+	}
+	if opts.Limit > 0 {
+		values.Add("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		values.Add("offset", strconv.Itoa(opts.Offset))
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "activity/get", values)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if (res.StatusCode / 100) != 2 {
+		res.Body.Close()
+		return nil, fmt.Errorf("failed to get activity, status code: %d", res.StatusCode)
+	}
+	var out ActivityEvents
+	if err = decodeBody(res, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Pager pages through the events matching opts, from the offset of opts.
+func (c *ActivityClient) Pager(opts *ActivityGetOpts) *EventPager {
+	page := *opts
+	if page.Limit <= 0 {
+		page.Limit = 100
+	}
+	p := &EventPager{}
+	p.pager = newPager(PageInfo{Offset: page.Offset, Limit: page.Limit}, func(ctx context.Context, info PageInfo) (int, PageInfo, error) {
+		page.Offset = info.Offset
+		res, err := c.Get(ctx, &page)
+		if err != nil {
+			return 0, info, err
+		}
+		p.events = res.Events
+		return len(res.Events), nextOffset(info, len(res.Events)), nil
+	})
+	return p
+}
+
+// GetAllPages follows the offset of opts until every matching event is fetched.
+func (c *ActivityClient) GetAllPages(ctx context.Context, opts *ActivityGetOpts) ([]Event, error) {
+	var all []Event
+	p := c.Pager(opts)
+	for p.Next(ctx) {
+		all = append(all, p.Events()...)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
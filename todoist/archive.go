@@ -0,0 +1,84 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type ArchiveClient struct {
+	*Client
+}
+
+// ArchiveItemsOpts selects the completed items of a project, a section or a parent item.
+type ArchiveItemsOpts struct {
+	ProjectID ID
+	SectionID ID
+	ParentID  ID
+	// Limit is the number of items per page, up to 100.
+	Limit int
+}
+
+type archiveItemsResponse struct {
+	Items      []Item `json:"items"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// Pager pages through the archived items of opts by the cursor of the server.
+func (c *ArchiveClient) Pager(opts *ArchiveItemsOpts) *ItemPager {
+	o := *opts
+	p := &ItemPager{}
+	p.pager = newPager(PageInfo{Limit: o.Limit}, func(ctx context.Context, info PageInfo) (int, PageInfo, error) {
+		items, next, err := c.items(ctx, &o, info)
+		if err != nil {
+			return 0, info, err
+		}
+		p.items = items
+		return len(items), next, nil
+	})
+	return p
+}
+
+func (c *ArchiveClient) items(ctx context.Context, opts *ArchiveItemsOpts, info PageInfo) ([]Item, PageInfo, error) {
+	values := url.Values{}
+	switch {
+	case !opts.ParentID.IsZero():
+		values.Add("parent_id", opts.ParentID.String())
+	case !opts.SectionID.IsZero():
+		values.Add("section_id", opts.SectionID.String())
+	case !opts.ProjectID.IsZero():
+		values.Add("project_id", opts.ProjectID.String())
+	default:
+		return nil, info, errors.New("archived items require a project, section or parent id")
+	}
+	if opts.Limit > 0 {
+		values.Add("limit", strconv.Itoa(opts.Limit))
+	}
+	if len(info.Cursor) != 0 {
+		values.Add("cursor", info.Cursor)
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, "archive/items", values)
+	if err != nil {
+		return nil, info, err
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, info, err
+	}
+	if (res.StatusCode / 100) != 2 {
+		res.Body.Close()
+		return nil, info, fmt.Errorf("failed to get archived items, status code: %d", res.StatusCode)
+	}
+	var out archiveItemsResponse
+	if err = decodeBody(res, &out); err != nil {
+		return nil, info, err
+	}
+	info.Offset += len(out.Items)
+	info.Cursor = out.NextCursor
+	info.HasMore = out.HasMore && len(out.NextCursor) != 0
+	return out.Items, info, nil
+}
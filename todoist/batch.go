@@ -0,0 +1,180 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MaxCommands is the number of commands the sync api accepts in a request.
+const MaxCommands = 100
+
+// Operation is a change of a batch. It queues its commands with the clients, such as
+//
+//	func(c *Client) error {
+//		_, err := c.Item.Add(Item{Content: "buy milk"})
+//		return err
+//	}
+type Operation func(c *Client) error
+
+// OperationResult is the result of an operation of a batch.
+type OperationResult struct {
+	// Commands are the commands queued by the operation.
+	Commands []Command
+	// Err is the error of queuing the commands, or of the server on any of them.
+	Err error
+}
+
+// CommandError is a command which the server rejected.
+type CommandError struct {
+	Command   Command
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"error"`
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s failed: %s (%d)", e.Command.Type, e.Message, e.ErrorCode)
+}
+
+// commandError returns the error of the sync status of the command, or nil when it is ok.
+func commandError(command Command, status json.RawMessage) error {
+	if len(status) == 0 || string(status) == `"ok"` {
+		return nil
+	}
+	e := &CommandError{Command: command}
+	if err := json.Unmarshal(status, e); err != nil || len(e.Message) == 0 {
+		e.Message = string(status)
+	}
+	return e
+}
+
+// BatchExecute runs the operations and commits their commands in requests of at most MaxCommands,
+// keeping the commands of an operation in the same request. onProgress, which may be nil,
+// is called with the number of committed operations after each request.
+// Temp ids of earlier requests are replaced by their ids in later ones.
+//
+// A failed request stops the batch and is returned. It is the Err of every operation which was not
+// committed. When it may succeed on a retry, its commands and the rest are kept as pending like on Commit.
+// The commands queued before stay queued for the next Commit.
+func (c *Client) BatchExecute(ctx context.Context, ops []Operation, onProgress func(done, total int)) ([]OperationResult, error) {
+	results := make([]OperationResult, len(ops))
+	queued := c.queue
+	defer func() { c.queue = queued }()
+	for i, op := range ops {
+		c.queue = []Command{}
+		if err := op(c); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Commands = c.queue
+		if len(c.queue) > MaxCommands {
+			results[i].Err = fmt.Errorf("operation queues %d commands, more than %d", len(c.queue), MaxCommands)
+		}
+	}
+
+	mapping := map[ID]ID{}
+	done := 0
+	for start := 0; start < len(ops); {
+		// fill the request with whole operations
+		end, n := start, 0
+		var commands []Command
+		for ; end < len(ops); end++ {
+			r := results[end]
+			if r.Err != nil {
+				continue
+			}
+			if n+len(r.Commands) > MaxCommands {
+				break
+			}
+			n += len(r.Commands)
+			commands = append(commands, r.Commands...)
+		}
+		if len(commands) > 0 {
+			commands, err := replaceTempIDs(commands, mapping)
+			if err != nil {
+				failRemaining(results[start:], err)
+				return results, err
+			}
+			state, err := c.sync(ctx, commands)
+			if err != nil {
+				if isTransient(err) {
+					if err := c.keepPending(remainingCommands(results[start:], mapping), err); err != nil {
+						c.Logger.Printf("pending: failed to keep the commands: %s", err)
+					}
+				}
+				failRemaining(results[start:], err)
+				return results, err
+			}
+			for tempID, id := range state.TempIDMapping {
+				mapping[tempID] = id
+			}
+			for i := start; i < end; i++ {
+				if results[i].Err != nil {
+					continue
+				}
+				for _, command := range results[i].Commands {
+					if err := commandError(command, state.SyncStatus[command.UUID]); err != nil {
+						results[i].Err = err
+						break
+					}
+				}
+			}
+		}
+		done += end - start
+		start = end
+		if onProgress != nil {
+			onProgress(done, len(ops))
+		}
+	}
+	return results, nil
+}
+
+// remainingCommands returns the commands of the results which were not committed.
+func remainingCommands(results []OperationResult, mapping map[ID]ID) []Command {
+	var commands []Command
+	for _, r := range results {
+		if r.Err == nil {
+			commands = append(commands, r.Commands...)
+		}
+	}
+	if res, err := replaceTempIDs(commands, mapping); err == nil {
+		return res
+	}
+	return commands
+}
+
+// failRemaining sets err to the results which have not failed yet.
+func failRemaining(results []OperationResult, err error) {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = err
+		}
+	}
+}
+
+// replaceTempIDs returns the commands with the temp ids of the mapping in their args replaced by the ids.
+func replaceTempIDs(commands []Command, mapping map[ID]ID) ([]Command, error) {
+	if len(mapping) == 0 {
+		return commands, nil
+	}
+	var pairs []string
+	for tempID, id := range mapping {
+		b, err := id.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, `"`+string(tempID)+`"`, string(b))
+	}
+	replacer := strings.NewReplacer(pairs...)
+	res := make([]Command, len(commands))
+	for i, command := range commands {
+		b, err := json.Marshal(command.Args)
+		if err != nil {
+			return nil, err
+		}
+		command.Args = json.RawMessage(replacer.Replace(string(b)))
+		res[i] = command
+	}
+	return res, nil
+}
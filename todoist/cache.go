@@ -0,0 +1,81 @@
+package todoist
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// lazyCache decodes a resource of the cache file on its first use,
+// so that commands using a few resources do not pay for decoding all of them.
+type lazyCache struct {
+	once sync.Once
+	load func()
+}
+
+func (l *lazyCache) ensure() {
+	if l != nil {
+		l.once.Do(l.load)
+	}
+}
+
+// skip marks the resource loaded without decoding it, for a resource replaced as a whole.
+func (l *lazyCache) skip() {
+	if l != nil {
+		l.once.Do(func() {})
+	}
+}
+
+// cacheFile is the cache file split into the raw json of each resource.
+type cacheFile struct {
+	once sync.Once
+	raw  map[string]json.RawMessage
+}
+
+// lazy returns the loader decoding the resource of the key in the cache file into v.
+func (c *Client) lazy(key string, v interface{}) *lazyCache {
+	l := &lazyCache{load: func() {
+		raw := c.readCacheFile()
+		b, ok := raw[key]
+		if !ok {
+			return
+		}
+		start := time.Now()
+		if err := json.Unmarshal(b, v); err != nil {
+			c.Logger.Printf("cache: failed to decode %s: %s", key, err)
+			return
+		}
+		c.Logger.Printf("cache: decoded %s in %s", key, time.Since(start))
+	}}
+	c.lazies = append(c.lazies, l)
+	return l
+}
+
+func (c *Client) readCacheFile() map[string]json.RawMessage {
+	c.cacheFile.once.Do(func() {
+		if !c.hasCache {
+			return
+		}
+		start := time.Now()
+		b, err := ioutil.ReadFile(filepath.Join(c.CacheDir, c.Token+".json"))
+		if err != nil {
+			c.Logger.Printf("cache: failed to read: %s", err)
+			return
+		}
+		if err = json.Unmarshal(b, &c.cacheFile.raw); err != nil {
+			c.Logger.Printf("cache: failed to split: %s", err)
+			return
+		}
+		c.Logger.Printf("cache: read %d bytes in %s", len(b), time.Since(start))
+	})
+	return c.cacheFile.raw
+}
+
+// loadCache decodes all the resources which are not decoded yet.
+func (c *Client) loadCache() {
+	for _, l := range c.lazies {
+		l.ensure()
+	}
+}
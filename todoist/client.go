@@ -4,14 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Client struct {
@@ -20,16 +21,35 @@ type Client struct {
 	Token      string
 	SyncToken  string
 	CacheDir   string
-	syncState  *SyncState
-	Logger     *log.Logger
-	Completed  *CompletedClient
-	Filter     *FilterClient
-	Item       *ItemClient
-	Label      *LabelClient
-	Project    *ProjectClient
-	Relation   *RelationClient
-	Note       *NoteClient
-	queue      []Command
+	// ResourceTypes are the resource types to sync, such as `["all", "-notes"]`. Empty means all.
+	ResourceTypes []string
+	// Incremental makes FullSync sync from the sync token of the cache instead of fetching
+	// all the resources again, which saves the bandwidth of metered connections.
+	Incremental bool
+	// DueParser parses the due dates given as text, ServerDueParser by default.
+	DueParser DueParser
+	// Store keeps the durable state, such as the pending commands, in CacheDir by default.
+	Store        Store
+	syncState    *SyncState
+	Logger       *log.Logger
+	Activity     *ActivityClient
+	Archive      *ArchiveClient
+	Completed    *CompletedClient
+	Filter       *FilterClient
+	Item         *ItemClient
+	Label        *LabelClient
+	Project      *ProjectClient
+	Relation     *RelationClient
+	Note         *NoteClient
+	ProjectNote  *ProjectNoteClient
+	Section      *SectionClient
+	Collaborator *CollaboratorClient
+	Upload       *UploadClient
+	User         *UserClient
+	queue        []Command
+	cacheFile    *cacheFile
+	hasCache     bool
+	lazies       []*lazyCache
 }
 
 func NewClient(endpoint, token, sync_token, cache_dir string, logger *log.Logger) (*Client, error) {
@@ -52,7 +72,7 @@ func NewClient(endpoint, token, sync_token, cache_dir string, logger *log.Logger
 	}
 
 	if len(cache_dir) == 0 {
-		cache_dir = "$HOME/.go-todoist"
+		cache_dir = DefaultDir()
 	}
 	cache_dir = os.ExpandEnv(cache_dir)
 	if _, err = os.Stat(cache_dir); err != nil {
@@ -71,19 +91,35 @@ func NewClient(endpoint, token, sync_token, cache_dir string, logger *log.Logger
 		Token:      token,
 		SyncToken:  sync_token,
 		CacheDir:   cache_dir,
+		Store:      FileStore{Dir: cache_dir},
 		syncState:  &SyncState{},
 		Logger:     logger,
+		cacheFile:  &cacheFile{},
 	}
 	if err = c.readCache(); err != nil {
 		c.resetState()
 	}
+	st := c.syncState
+	c.Activity = &ActivityClient{c}
+	c.Archive = &ArchiveClient{c}
 	c.Completed = &CompletedClient{c}
-	c.Filter = &FilterClient{c, &filterCache{&c.syncState.Filters}}
-	c.Item = &ItemClient{c, &itemCache{&c.syncState.Items}}
-	c.Label = &LabelClient{c, &labelCache{&c.syncState.Labels}}
-	c.Project = &ProjectClient{c, &projectCache{&c.syncState.Projects}}
+	c.Filter = &FilterClient{c, &filterCache{&st.Filters, c.lazy("filters", &st.Filters)}}
+	c.Item = &ItemClient{c, &itemCache{&st.Items, c.lazy("items", &st.Items)}}
+	c.Label = &LabelClient{c, &labelCache{&st.Labels, c.lazy("labels", &st.Labels)}}
+	c.Project = &ProjectClient{c, &projectCache{&st.Projects, c.lazy("projects", &st.Projects)}}
 	c.Relation = &RelationClient{c}
-	c.Note = &NoteClient{c, &noteCache{&c.syncState.Notes}}
+	c.Note = &NoteClient{c, &noteCache{&st.Notes, c.lazy("notes", &st.Notes)}}
+	c.ProjectNote = &ProjectNoteClient{c, &noteCache{&st.ProjectNotes, c.lazy("project_notes", &st.ProjectNotes)}}
+	c.Section = &SectionClient{c, &sectionCache{&st.Sections, c.lazy("sections", &st.Sections)}}
+	c.Collaborator = &CollaboratorClient{c, &collaboratorCache{
+		&st.Collaborators,
+		&st.CollaboratorStates,
+		c.lazy("collaborators", &st.Collaborators),
+		c.lazy("collaborator_states", &st.CollaboratorStates),
+	}}
+	c.Upload = &UploadClient{c}
+	c.User = &UserClient{c, &userCache{&st.User, c.lazy("user", &st.User)}}
+	c.lazy("reminders", &st.Reminders)
 	return c, nil
 }
 
@@ -126,40 +162,57 @@ func decodeBody(resp *http.Response, out interface{}) error {
 }
 
 func (c *Client) Sync(ctx context.Context, commands []Command) error {
+	_, err := c.sync(ctx, commands)
+	return err
+}
+
+// sync sends the commands and returns the response, whose state is merged into the cache.
+func (c *Client) sync(ctx context.Context, commands []Command) (*SyncState, error) {
 	b, err := json.Marshal(commands)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	types := c.ResourceTypes
+	if len(types) == 0 {
+		types = []string{"all"}
+	}
+	t, err := json.Marshal(types)
+	if err != nil {
+		return nil, err
 	}
 	values := url.Values{
 		"sync_token":           {c.SyncToken},
 		"day_orders_timestamp": {""},
-		"resource_types":       {"[\"all\"]"},
+		"resource_types":       {string(t)},
 		"commands":             {string(b)},
 	}
 	req, err := c.newSyncRequest(ctx, values)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if (res.StatusCode / 100) != 2 {
-		return fmt.Errorf("failed to sync, status code: %d, command: %v", res.StatusCode, commands)
+		return nil, syncStatusError{res.StatusCode, commands}
 	}
 	var out SyncState
 	err = decodeBody(res, &out)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// TODO: replace temp_id mapping
 	c.updateState(&out)
 	c.writeCache()
-	return nil
+	c.hasCache = true
+	return &out, nil
 }
 
 func (c *Client) FullSync(ctx context.Context, commands []Command) error {
+	if c.Incremental && c.hasCache {
+		return c.Sync(ctx, commands)
+	}
 	c.resetState()
 	return c.Sync(ctx, commands)
 }
@@ -169,17 +222,111 @@ func (c *Client) Commit(ctx context.Context) error {
 		return nil
 	}
 	err := c.Sync(ctx, c.queue)
+	if err != nil && isTransient(err) {
+		if err := c.keepPending(c.queue, err); err != nil {
+			c.Logger.Printf("pending: failed to keep the commands: %s", err)
+		}
+	}
 	c.queue = []Command{}
 	return err
 }
 
+// Snapshot returns the state assembled from the caches of all resources.
+func (c *Client) Snapshot() SyncState {
+	c.loadCache()
+	return SyncState{
+		SyncToken:          c.SyncToken,
+		User:               c.User.cache.get(),
+		Projects:           c.Project.GetAll(),
+		ProjectNotes:       c.ProjectNote.GetAll(),
+		Items:              c.Item.GetAll(),
+		Notes:              c.Note.cache.getAll(),
+		Labels:             c.Label.GetAll(),
+		Filters:            c.Filter.GetAll(),
+		Reminders:          c.syncState.Reminders,
+		Sections:           c.Section.GetAll(),
+		Collaborators:      c.Collaborator.GetAll(),
+		CollaboratorStates: c.Collaborator.cache.getStates(),
+	}
+}
+
 func (c *Client) ResetSyncToken() {
 	c.SyncToken = "*"
 }
 
 func (c *Client) resetState() {
 	c.SyncToken = "*"
-	c.syncState = &SyncState{}
+}
+
+// syncs reports whether the resource of the key is in ResourceTypes.
+func (c *Client) syncs(key string) bool {
+	if len(c.ResourceTypes) == 0 {
+		return true
+	}
+	res := false
+	for _, t := range c.ResourceTypes {
+		if t == "-"+key {
+			return false
+		}
+		if t == "all" || t == key {
+			res = true
+		}
+	}
+	return res
+}
+
+// clearCaches empties the caches of the synced resources, which a full sync fetches as a whole.
+func (c *Client) clearCaches() {
+	if c.syncs("filters") {
+		c.Filter.cache.lazy.skip()
+		c.Filter.cache.cache = &[]Filter{}
+	}
+	if c.syncs("items") {
+		c.Item.cache.lazy.skip()
+		c.Item.cache.cache = &[]Item{}
+	}
+	if c.syncs("labels") {
+		c.Label.cache.lazy.skip()
+		c.Label.cache.cache = &[]Label{}
+	}
+	if c.syncs("projects") {
+		c.Project.cache.lazy.skip()
+		c.Project.cache.cache = &[]Project{}
+	}
+	if c.syncs("notes") {
+		c.Note.cache.lazy.skip()
+		c.Note.cache.cache = &[]Note{}
+	}
+	if c.syncs("project_notes") {
+		c.ProjectNote.cache.lazy.skip()
+		c.ProjectNote.cache.cache = &[]Note{}
+	}
+	if c.syncs("sections") {
+		c.Section.cache.lazy.skip()
+		c.Section.cache.cache = &[]Section{}
+	}
+	if c.syncs("collaborators") {
+		c.Collaborator.cache.lazyCollaborators.skip()
+		c.Collaborator.cache.collaborators = &[]Collaborator{}
+		c.Collaborator.cache.lazyStates.skip()
+		c.Collaborator.cache.states = &[]CollaboratorState{}
+	}
+	if c.syncs("reminders") {
+		c.loadCache()
+		c.syncState.Reminders = nil
+	}
+}
+
+// dropTempID removes the resource added with the temp id, which the server returns with its real id.
+func (c *Client) dropTempID(id ID) {
+	deleted := Entity{ID: id, IsDeleted: true}
+	c.Filter.cache.store(Filter{Entity: deleted})
+	c.Item.cache.store(Item{Entity: deleted})
+	c.Label.cache.store(Label{Entity: deleted})
+	c.Project.cache.store(Project{Entity: deleted})
+	c.Note.cache.store(Note{Entity: deleted})
+	c.ProjectNote.cache.store(Note{Entity: deleted})
+	c.Section.cache.store(Section{Entity: deleted})
 }
 
 func (c *Client) updateState(state *SyncState) {
@@ -192,8 +339,16 @@ func (c *Client) updateState(state *SyncState) {
 	- live_notifications_last_read_id
 	- locations
 	- settings_notifications
-	- user
 	*/
+	if state.FullSync {
+		c.clearCaches()
+	}
+	for tempID := range state.TempIDMapping {
+		c.dropTempID(tempID)
+	}
+	if !state.User.ID.IsZero() {
+		c.User.cache.store(state.User)
+	}
 	for _, filter := range state.Filters {
 		c.Filter.cache.store(filter)
 	}
@@ -210,24 +365,54 @@ func (c *Client) updateState(state *SyncState) {
 		c.Note.cache.store(note)
 	}
 	for _, note := range state.ProjectNotes {
-		c.Note.cache.store(note)
+		c.ProjectNote.cache.store(note)
 	}
-	c.syncState = state
+	for _, section := range state.Sections {
+		c.Section.cache.store(section)
+	}
+	for _, collaborator := range state.Collaborators {
+		c.Collaborator.cache.store(collaborator)
+	}
+	for _, s := range state.CollaboratorStates {
+		c.Collaborator.cache.storeState(s)
+	}
+	c.loadCache()
+	for _, r := range state.Reminders {
+		var res []Reminder
+		for _, old := range c.syncState.Reminders {
+			if !old.Equal(r) {
+				res = append(res, old)
+			}
+		}
+		if !r.IsDeleted {
+			res = append(res, r)
+		}
+		c.syncState.Reminders = res
+	}
+	// keep the merged state, which is written to the cache
+	*c.syncState = c.Snapshot()
 }
 
-func (c *Client) readCache() error {
-	b, err := ioutil.ReadFile(path.Join(c.CacheDir, c.Token+".json"))
+// SyncedAt returns the time of the last sync written to the cache, or zero time without a cache.
+func (c *Client) SyncedAt() time.Time {
+	fi, err := os.Stat(filepath.Join(c.CacheDir, c.Token+".sync"))
 	if err != nil {
-		return err
+		return time.Time{}
 	}
-	if err = json.Unmarshal(b, c.syncState); err != nil {
+	return fi.ModTime()
+}
+
+// readCache reads the sync token of the cache. The resources are decoded on their first use.
+func (c *Client) readCache() error {
+	if _, err := os.Stat(filepath.Join(c.CacheDir, c.Token+".json")); err != nil {
 		return err
 	}
-	b, err = ioutil.ReadFile(path.Join(c.CacheDir, c.Token+".sync"))
+	b, err := ioutil.ReadFile(filepath.Join(c.CacheDir, c.Token+".sync"))
 	if err != nil {
 		return err
 	}
 	c.SyncToken = string(b)
+	c.hasCache = true
 	return nil
 }
 
@@ -239,10 +424,10 @@ func (c *Client) writeCache() error {
 	if err != nil {
 		return err
 	}
-	if err = ioutil.WriteFile(path.Join(c.CacheDir, c.Token+".json"), b, 0644); err != nil {
+	if err = ioutil.WriteFile(filepath.Join(c.CacheDir, c.Token+".json"), b, 0644); err != nil {
 		return err
 	}
-	if err = ioutil.WriteFile(path.Join(c.CacheDir, c.Token+".sync"), []byte(c.SyncToken), 0644); err != nil {
+	if err = ioutil.WriteFile(filepath.Join(c.CacheDir, c.Token+".sync"), []byte(c.SyncToken), 0644); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,111 @@
+package todoist
+
+type Collaborator struct {
+	ID       ID     `json:"id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	Timezone string `json:"timezone"`
+	ImageID  string `json:"image_id"`
+}
+
+func (c Collaborator) String() string {
+	return c.FullName
+}
+
+func (c Collaborator) ColorString() string {
+	return c.String()
+}
+
+type CollaboratorState struct {
+	ProjectID ID      `json:"project_id"`
+	UserID    ID      `json:"user_id"`
+	State     string  `json:"state"`
+	IsDeleted IntBool `json:"is_deleted"`
+}
+
+type CollaboratorClient struct {
+	*Client
+	cache *collaboratorCache
+}
+
+func (c CollaboratorClient) GetAll() []Collaborator {
+	return c.cache.getAll()
+}
+
+func (c CollaboratorClient) Resolve(id ID) *Collaborator {
+	for _, collaborator := range c.cache.getAll() {
+		if collaborator.ID == id {
+			return &collaborator
+		}
+	}
+	return nil
+}
+
+// FindByProjectID returns the active collaborators of the given project.
+func (c CollaboratorClient) FindByProjectID(projectID ID) []Collaborator {
+	var res []Collaborator
+	for _, s := range c.cache.getStates() {
+		if s.ProjectID != projectID || s.State != "active" {
+			continue
+		}
+		if collaborator := c.Resolve(s.UserID); collaborator != nil {
+			res = append(res, *collaborator)
+		}
+	}
+	return res
+}
+
+type collaboratorCache struct {
+	collaborators     *[]Collaborator
+	states            *[]CollaboratorState
+	lazyCollaborators *lazyCache
+	lazyStates        *lazyCache
+}
+
+func (c *collaboratorCache) getAll() []Collaborator {
+	c.lazyCollaborators.ensure()
+	return *c.collaborators
+}
+
+func (c *collaboratorCache) getStates() []CollaboratorState {
+	c.lazyStates.ensure()
+	return *c.states
+}
+
+func (c *collaboratorCache) store(collaborator Collaborator) {
+	c.lazyCollaborators.ensure()
+	var res []Collaborator
+	isNew := true
+	for _, i := range *c.collaborators {
+		if i.ID == collaborator.ID {
+			res = append(res, collaborator)
+			isNew = false
+		} else {
+			res = append(res, i)
+		}
+	}
+	if isNew {
+		res = append(res, collaborator)
+	}
+	c.collaborators = &res
+}
+
+func (c *collaboratorCache) storeState(state CollaboratorState) {
+	c.lazyStates.ensure()
+	var res []CollaboratorState
+	isNew := true
+	for _, s := range *c.states {
+		if s.ProjectID == state.ProjectID && s.UserID == state.UserID {
+			if !state.IsDeleted {
+				res = append(res, state)
+			}
+			isNew = false
+		} else {
+			res = append(res, s)
+		}
+	}
+	if isNew && !state.IsDeleted.Bool() {
+		res = append(res, state)
+	}
+	c.states = &res
+}
@@ -19,6 +19,12 @@ func TestIntBool_UnmarshalJSON(t *testing.T) {
 		t.Errorf("Expect %v, but got %v", IntBool(false), v)
 	}
 
+	s = "true"
+	err = v.UnmarshalJSON([]byte(s))
+	if err != nil || v != IntBool(true) {
+		t.Errorf("Expect %v, but got %v", IntBool(true), v)
+	}
+
 	s = "10"
 	err = v.UnmarshalJSON([]byte(s))
 	if err == nil {
@@ -2,7 +2,9 @@ package todoist
 
 import (
 	"context"
+	"net/http"
 	"net/url"
+	"strconv"
 )
 
 type Stats struct {
@@ -121,3 +123,92 @@ func (c *CompletedClient) GetAll() (*CompletedItems, error) {
 	decodeBody(res, &out)
 	return &out, nil
 }
+
+// CompletedGetAllOpts narrows the completed items returned by GetAllWithOpts.
+// Zero values are not sent to the server.
+type CompletedGetAllOpts struct {
+	ProjectID ID
+	Limit     int
+	Offset    int
+	Since     Time
+	Until     Time
+	// AnnotateNotes adds the notes of each item.
+	AnnotateNotes bool
+	// AnnotateItems adds the full item object of each item.
+	AnnotateItems bool
+}
+
+func (c *CompletedClient) GetAllWithOpts(ctx context.Context, opts *CompletedGetAllOpts) (*CompletedItems, error) {
+	const layout = "2006-01-02T15:04"
+	values := url.Values{}
+	if !opts.ProjectID.IsZero() {
+		values.Add("project_id", opts.ProjectID.String())
+	}
+	if opts.Limit > 0 {
+		values.Add("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		values.Add("offset", strconv.Itoa(opts.Offset))
+	}
+	if !opts.Since.IsZero() {
+		values.Add("since", opts.Since.UTC().Format(layout))
+	}
+	if !opts.Until.IsZero() {
+		values.Add("until", opts.Until.UTC().Format(layout))
+	}
+	if opts.AnnotateNotes {
+		values.Add("annotate_notes", "true")
+	}
+	if opts.AnnotateItems {
+		values.Add("annotate_items", "true")
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "completed/get_all", values)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	var out CompletedItems
+	err = decodeBody(res, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Pager pages through the completed items matching opts, from the offset of opts.
+func (c *CompletedClient) Pager(opts *CompletedGetAllOpts) *CompletedPager {
+	page := *opts
+	if page.Limit <= 0 {
+		page.Limit = 200
+	}
+	p := &CompletedPager{}
+	p.pager = newPager(PageInfo{Offset: page.Offset, Limit: page.Limit}, func(ctx context.Context, info PageInfo) (int, PageInfo, error) {
+		page.Offset = info.Offset
+		res, err := c.GetAllWithOpts(ctx, &page)
+		if err != nil {
+			return 0, info, err
+		}
+		p.items = res
+		return len(res.Items), nextOffset(info, len(res.Items)), nil
+	})
+	return p
+}
+
+// GetAllPages follows the offset of opts until every matching completed item is fetched.
+func (c *CompletedClient) GetAllPages(ctx context.Context, opts *CompletedGetAllOpts) (*CompletedItems, error) {
+	all := CompletedItems{Projects: map[ID]Project{}}
+	p := c.Pager(opts)
+	for p.Next(ctx) {
+		all.Items = append(all.Items, p.Items().Items...)
+		for k, v := range p.Items().Projects {
+			all.Projects[k] = v
+		}
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return &all, nil
+}
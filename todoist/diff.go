@@ -0,0 +1,231 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Changes are the differences between two snapshots of an account,
+// as the resources added, removed and modified per resource type.
+type Changes struct {
+	Projects     ProjectChanges
+	Sections     SectionChanges
+	Items        ItemChanges
+	Notes        NoteChanges
+	ProjectNotes NoteChanges
+	Labels       LabelChanges
+	Filters      FilterChanges
+}
+
+type ProjectChanges struct {
+	Added    []Project
+	Removed  []Project
+	Modified []ProjectChange
+}
+
+type ProjectChange struct {
+	Before, After Project
+}
+
+type SectionChanges struct {
+	Added    []Section
+	Removed  []Section
+	Modified []SectionChange
+}
+
+type SectionChange struct {
+	Before, After Section
+}
+
+type ItemChanges struct {
+	Added    []Item
+	Removed  []Item
+	Modified []ItemChange
+}
+
+type ItemChange struct {
+	Before, After Item
+}
+
+type NoteChanges struct {
+	Added    []Note
+	Removed  []Note
+	Modified []NoteChange
+}
+
+type NoteChange struct {
+	Before, After Note
+}
+
+type LabelChanges struct {
+	Added    []Label
+	Removed  []Label
+	Modified []LabelChange
+}
+
+type LabelChange struct {
+	Before, After Label
+}
+
+type FilterChanges struct {
+	Added    []Filter
+	Removed  []Filter
+	Modified []FilterChange
+}
+
+type FilterChange struct {
+	Before, After Filter
+}
+
+// DiffStates compares the resources of the snapshots a and b by id. A resource is modified
+// when any of its fields differs. Deleted resources are treated as missing.
+func DiffStates(a, b SyncState) Changes {
+	var c Changes
+	added, removed, modified := diffIndexes(a.Projects, b.Projects)
+	for _, j := range added {
+		c.Projects.Added = append(c.Projects.Added, b.Projects[j])
+	}
+	for _, i := range removed {
+		c.Projects.Removed = append(c.Projects.Removed, a.Projects[i])
+	}
+	for _, m := range modified {
+		c.Projects.Modified = append(c.Projects.Modified, ProjectChange{a.Projects[m[0]], b.Projects[m[1]]})
+	}
+
+	added, removed, modified = diffIndexes(a.Sections, b.Sections)
+	for _, j := range added {
+		c.Sections.Added = append(c.Sections.Added, b.Sections[j])
+	}
+	for _, i := range removed {
+		c.Sections.Removed = append(c.Sections.Removed, a.Sections[i])
+	}
+	for _, m := range modified {
+		c.Sections.Modified = append(c.Sections.Modified, SectionChange{a.Sections[m[0]], b.Sections[m[1]]})
+	}
+
+	added, removed, modified = diffIndexes(a.Items, b.Items)
+	for _, j := range added {
+		c.Items.Added = append(c.Items.Added, b.Items[j])
+	}
+	for _, i := range removed {
+		c.Items.Removed = append(c.Items.Removed, a.Items[i])
+	}
+	for _, m := range modified {
+		c.Items.Modified = append(c.Items.Modified, ItemChange{a.Items[m[0]], b.Items[m[1]]})
+	}
+
+	c.Notes = diffNotes(a.Notes, b.Notes)
+	c.ProjectNotes = diffNotes(a.ProjectNotes, b.ProjectNotes)
+
+	added, removed, modified = diffIndexes(a.Labels, b.Labels)
+	for _, j := range added {
+		c.Labels.Added = append(c.Labels.Added, b.Labels[j])
+	}
+	for _, i := range removed {
+		c.Labels.Removed = append(c.Labels.Removed, a.Labels[i])
+	}
+	for _, m := range modified {
+		c.Labels.Modified = append(c.Labels.Modified, LabelChange{a.Labels[m[0]], b.Labels[m[1]]})
+	}
+
+	added, removed, modified = diffIndexes(a.Filters, b.Filters)
+	for _, j := range added {
+		c.Filters.Added = append(c.Filters.Added, b.Filters[j])
+	}
+	for _, i := range removed {
+		c.Filters.Removed = append(c.Filters.Removed, a.Filters[i])
+	}
+	for _, m := range modified {
+		c.Filters.Modified = append(c.Filters.Modified, FilterChange{a.Filters[m[0]], b.Filters[m[1]]})
+	}
+	return c
+}
+
+func diffNotes(a, b []Note) NoteChanges {
+	var c NoteChanges
+	added, removed, modified := diffIndexes(a, b)
+	for _, j := range added {
+		c.Added = append(c.Added, b[j])
+	}
+	for _, i := range removed {
+		c.Removed = append(c.Removed, a[i])
+	}
+	for _, m := range modified {
+		c.Modified = append(c.Modified, NoteChange{a[m[0]], b[m[1]]})
+	}
+	return c
+}
+
+// IsEmpty reports whether the snapshots have the same resources.
+func (c Changes) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Len returns the number of changed resources.
+func (c Changes) Len() int {
+	n := 0
+	for _, l := range [][3]int{
+		{len(c.Projects.Added), len(c.Projects.Removed), len(c.Projects.Modified)},
+		{len(c.Sections.Added), len(c.Sections.Removed), len(c.Sections.Modified)},
+		{len(c.Items.Added), len(c.Items.Removed), len(c.Items.Modified)},
+		{len(c.Notes.Added), len(c.Notes.Removed), len(c.Notes.Modified)},
+		{len(c.ProjectNotes.Added), len(c.ProjectNotes.Removed), len(c.ProjectNotes.Modified)},
+		{len(c.Labels.Added), len(c.Labels.Removed), len(c.Labels.Modified)},
+		{len(c.Filters.Added), len(c.Filters.Removed), len(c.Filters.Modified)},
+	} {
+		n += l[0] + l[1] + l[2]
+	}
+	return n
+}
+
+type diffable interface {
+	Identifier
+	deleted() bool
+}
+
+// diffIndexes matches the resources of the slices a and b by id. It returns the indexes
+// of the added resources in b, of the removed ones in a, and the index pairs of the modified ones.
+func diffIndexes(a, b interface{}) (added, removed []int, modified [][2]int) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	index := map[ID]int{}
+	for i := 0; i < va.Len(); i++ {
+		if r := va.Index(i).Interface().(diffable); !r.deleted() {
+			index[r.getID()] = i
+		}
+	}
+	found := map[ID]bool{}
+	for j := 0; j < vb.Len(); j++ {
+		r := vb.Index(j).Interface().(diffable)
+		if r.deleted() {
+			continue
+		}
+		i, ok := index[r.getID()]
+		if !ok {
+			added = append(added, j)
+			continue
+		}
+		found[r.getID()] = true
+		if !sameJSON(va.Index(i).Interface(), r) {
+			modified = append(modified, [2]int{i, j})
+		}
+	}
+	for i := 0; i < va.Len(); i++ {
+		if r := va.Index(i).Interface().(diffable); !r.deleted() && !found[r.getID()] {
+			removed = append(removed, i)
+		}
+	}
+	return added, removed, modified
+}
+
+func sameJSON(x, y interface{}) bool {
+	bx, err := json.Marshal(x)
+	if err != nil {
+		return false
+	}
+	by, err := json.Marshal(y)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(bx, by)
+}
@@ -0,0 +1,56 @@
+package todoist
+
+import "testing"
+
+func TestDiffStates(t *testing.T) {
+	a := SyncState{
+		Projects: []Project{
+			{Entity: Entity{ID: "1"}, Name: "Inbox"},
+			{Entity: Entity{ID: "2"}, Name: "Work"},
+			{Entity: Entity{ID: "3"}, Name: "Old"},
+		},
+		Items: []Item{
+			{Entity: Entity{ID: "10"}, ProjectID: "1", Content: "buy milk"},
+			{Entity: Entity{ID: "11"}, ProjectID: "2", Content: "write report"},
+			{Entity: Entity{ID: "12", IsDeleted: true}, ProjectID: "2", Content: "deleted"},
+		},
+		Labels: []Label{{Entity: Entity{ID: "20"}, Name: "deep"}},
+	}
+	b := SyncState{
+		Projects: []Project{
+			{Entity: Entity{ID: "1"}, Name: "Inbox"},
+			{Entity: Entity{ID: "2"}, Name: "Work", Color: 30},
+		},
+		Items: []Item{
+			{Entity: Entity{ID: "10"}, ProjectID: "1", Content: "buy milk"},
+			{Entity: Entity{ID: "11"}, ProjectID: "2", Content: "write the report"},
+			{Entity: Entity{ID: "13"}, ProjectID: "1", Content: "call mom"},
+		},
+		Labels: []Label{{Entity: Entity{ID: "20"}, Name: "deep"}},
+	}
+	c := DiffStates(a, b)
+	if len(c.Projects.Added) != 0 || len(c.Projects.Removed) != 1 || c.Projects.Removed[0].ID != "3" {
+		t.Errorf("expect project 3 removed, but got %+v", c.Projects)
+	}
+	if len(c.Projects.Modified) != 1 || c.Projects.Modified[0].Before.Color != 0 || c.Projects.Modified[0].After.Color != 30 {
+		t.Errorf("expect project 2 modified, but got %+v", c.Projects.Modified)
+	}
+	if len(c.Items.Added) != 1 || c.Items.Added[0].ID != "13" {
+		t.Errorf("expect item 13 added, but got %+v", c.Items.Added)
+	}
+	if len(c.Items.Removed) != 0 {
+		t.Errorf("expect deleted items are not removed, but got %+v", c.Items.Removed)
+	}
+	if len(c.Items.Modified) != 1 || c.Items.Modified[0].After.Content != "write the report" {
+		t.Errorf("expect item 11 modified, but got %+v", c.Items.Modified)
+	}
+	if len(c.Labels.Added)+len(c.Labels.Removed)+len(c.Labels.Modified) != 0 {
+		t.Errorf("expect no label changes, but got %+v", c.Labels)
+	}
+	if c.Len() != 4 {
+		t.Errorf("expect 4 changes, but got %d", c.Len())
+	}
+	if !DiffStates(a, a).IsEmpty() {
+		t.Error("expect no changes between the same snapshots")
+	}
+}
@@ -0,0 +1,12 @@
+package todoist
+
+import "os"
+
+// DefaultDir is where the cache is kept unless NewClient is given a directory.
+// The TODOIST_DIR environment variable overrides it.
+func DefaultDir() string {
+	if dir := os.Getenv("TODOIST_DIR"); len(dir) != 0 {
+		return dir
+	}
+	return platformDir()
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package todoist
+
+import "os"
+
+// platformDir is the default of DefaultDir.
+func platformDir() string {
+	return os.ExpandEnv("$HOME/.go-todoist")
+}
@@ -0,0 +1,14 @@
+package todoist
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// platformDir is the default of DefaultDir.
+func platformDir() string {
+	if dir := os.Getenv("APPDATA"); len(dir) != 0 {
+		return filepath.Join(dir, "go-todoist")
+	}
+	return filepath.Join(os.Getenv("USERPROFILE"), ".go-todoist")
+}
@@ -0,0 +1,43 @@
+package todoist
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Due is the due date of an item.
+type Due struct {
+	Date        Time   `json:"date"`
+	Timezone    string `json:"timezone"`
+	IsRecurring bool   `json:"is_recurring"`
+	String      string `json:"string"`
+	Lang        string `json:"lang"`
+}
+
+// DueParser turns the text of a due date, such as `tomorrow at 10` or `every monday`,
+// into a due. A parser may leave the date zero to let the server parse the string.
+type DueParser interface {
+	ParseDue(text string, now time.Time) (Due, error)
+}
+
+// DueParserFunc adapts a function to a DueParser.
+type DueParserFunc func(text string, now time.Time) (Due, error)
+
+func (f DueParserFunc) ParseDue(text string, now time.Time) (Due, error) {
+	return f(text, now)
+}
+
+// ServerDueParser is the default DueParser. It leaves the text to the natural language
+// parser of the server, which understands the language Lang, or the language of the user when empty.
+type ServerDueParser struct {
+	Lang string
+}
+
+func (p ServerDueParser) ParseDue(text string, now time.Time) (Due, error) {
+	text = strings.TrimSpace(text)
+	if len(text) == 0 {
+		return Due{}, errors.New("empty due date")
+	}
+	return Due{String: text, Lang: p.Lang}, nil
+}
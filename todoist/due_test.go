@@ -0,0 +1,54 @@
+package todoist
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestItemClient_SetDue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "todoist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	client, err := NewClient("", "token", "*", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var item Item
+	if err = client.Item.SetDue(&item, " tomorrow at 10 "); err != nil {
+		t.Fatal(err)
+	}
+	if item.Due.String != "tomorrow at 10" || !item.Due.Date.IsZero() {
+		t.Errorf("expect the string only left to the server, but got %+v", item.Due)
+	}
+	if err = client.Item.SetDue(&item, ""); err == nil {
+		t.Error("expect error, but no error")
+	}
+
+	// a parser of the application decides the date by itself
+	client.DueParser = DueParserFunc(func(text string, now time.Time) (Due, error) {
+		if text != "demain" {
+			return Due{}, errors.New("unknown date")
+		}
+		return Due{Date: Time{time.Date(2019, 3, 11, 0, 0, 0, 0, time.UTC)}, String: text, Lang: "fr"}, nil
+	})
+	if err = client.Item.SetDue(&item, "demain"); err != nil {
+		t.Fatal(err)
+	}
+	if item.Due.Date.Format(dateLayout) != "2019-03-11" || item.Due.Lang != "fr" {
+		t.Errorf("expect the due of the parser, but got %+v", item.Due)
+	}
+	if err = client.Item.SetDue(&item, "tomorrow"); err == nil {
+		t.Error("expect error of the parser, but no error")
+	}
+	client.DueParser = DueParserFunc(func(text string, now time.Time) (Due, error) {
+		return Due{}, nil
+	})
+	if err = client.Item.SetDue(&item, "tomorrow"); err == nil {
+		t.Error("expect error for an empty due, but no error")
+	}
+}
@@ -21,3 +21,7 @@ func (e Entity) Equal(entity Identifier) bool {
 type Resolver interface {
 	Resolve(id ID) *Entity
 }
+
+func (e Entity) deleted() bool {
+	return e.IsDeleted.Bool()
+}
@@ -167,13 +167,16 @@ func (c FilterClient) FindByName(substr string) []Filter {
 
 type filterCache struct {
 	cache *[]Filter
+	lazy  *lazyCache
 }
 
 func (c *filterCache) getAll() []Filter {
+	c.lazy.ensure()
 	return *c.cache
 }
 
 func (c *filterCache) resolve(id ID) *Filter {
+	c.lazy.ensure()
 	for _, filter := range *c.cache {
 		if filter.ID == id {
 			return &filter
@@ -183,6 +186,7 @@ func (c *filterCache) resolve(id ID) *Filter {
 }
 
 func (c *filterCache) store(filter Filter) {
+	c.lazy.ensure()
 	var res []Filter
 	isNew := true
 	for _, f := range *c.cache {
@@ -202,6 +206,7 @@ func (c *filterCache) store(filter Filter) {
 }
 
 func (c *filterCache) remove(filter Filter) {
+	c.lazy.ensure()
 	var res []Filter
 	for _, f := range *c.cache {
 		if !f.Equal(filter) {
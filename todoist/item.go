@@ -12,33 +12,50 @@ import (
 
 type Item struct {
 	Entity
-	UserID    ID     `json:"user_id,omitempty"`
-	ProjectID ID     `json:"project_id,omitempty"`
-	Content   string `json:"content"`
-	Due       struct {
-		Date        Time   `json:"date"`
-		Timezone    string `json:"timezone"`
-		IsRecurring bool   `json:"is_recurring"`
-		String      string `json:"string"`
-		Lang        string `json:"lang"`
-	} `json:"due,omitempty"`
-	Priority       int  `json:"priority,omitempty"`
-	ParentID       ID   `json:"parent_id,omitempty"`
-	ChildOrder     int  `json:"child_order,omitempty"`
-	DayOrder       int  `json:"day_order,omitempty"`
-	Collapsed      int  `json:"collapsed,omitempty"`
-	Labels         []ID `json:"labels,omitempty"`
-	AssignedByUID  ID   `json:"assigned_by_uid,omitempty"`
-	ResponsibleUID ID   `json:"responsible_uid,omitempty"`
-	Checked        int  `json:"checked,omitempty"`
-	InHistory      int  `json:"in_history,omitempty"`
-	SyncID         int  `json:"sync_id,omitempty"`
-	DateAdded      Time `json:"date_added,omitempty"`
-	CompletedDate  Time `json:"completed_date"`
+	UserID         ID     `json:"user_id,omitempty"`
+	ProjectID      ID     `json:"project_id,omitempty"`
+	SectionID      ID     `json:"section_id,omitempty"`
+	Content        string `json:"content"`
+	Due            Due    `json:"due,omitempty"`
+	Priority       int    `json:"priority,omitempty"`
+	ParentID       ID     `json:"parent_id,omitempty"`
+	ChildOrder     int    `json:"child_order,omitempty"`
+	DayOrder       int    `json:"day_order,omitempty"`
+	Collapsed      int    `json:"collapsed,omitempty"`
+	Labels         []ID   `json:"labels,omitempty"`
+	AssignedByUID  ID     `json:"assigned_by_uid,omitempty"`
+	ResponsibleUID ID     `json:"responsible_uid,omitempty"`
+	Checked        int    `json:"checked,omitempty"`
+	InHistory      int    `json:"in_history,omitempty"`
+	SyncID         int    `json:"sync_id,omitempty"`
+	DateAdded      Time   `json:"date_added,omitempty"`
+	CompletedDate  Time   `json:"completed_date"`
+	// TaskID is the id of the completed item in the completed archive.
+	TaskID ID `json:"task_id,omitempty"`
+	// Duration is the estimated or tracked time of the item.
+	Duration *ItemDuration `json:"duration,omitempty"`
+	// Notes are the notes of a completed item, given by CompletedGetAllOpts.AnnotateNotes.
+	Notes []Note `json:"notes,omitempty"`
+	// ItemObject is the full completed item, given by CompletedGetAllOpts.AnnotateItems.
+	ItemObject *Item `json:"item_object,omitempty"`
+}
+
+// ItemDuration is the duration of an item in minutes or days.
+type ItemDuration struct {
+	Amount int    `json:"amount"`
+	Unit   string `json:"unit"`
+}
+
+// Duration returns the duration in minutes. A duration of days is not a time span and returns 0.
+func (d ItemDuration) Duration() time.Duration {
+	if d.Unit != "minute" {
+		return 0
+	}
+	return time.Duration(d.Amount) * time.Minute
 }
 
 func (i Item) IsOverDueDate() bool {
-	return i.Due.Date.Before(Time{time.Now().UTC()})
+	return i.Due.Date.isOverdue(time.Now())
 }
 
 func (i Item) IsChecked() bool {
@@ -83,6 +100,23 @@ func (c *ItemClient) Update(item Item) (*Item, error) {
 	return &item, nil
 }
 
+// SetDue sets the due of the item parsed from the text by the DueParser of the client.
+func (c *ItemClient) SetDue(item *Item, text string) error {
+	var parser DueParser = ServerDueParser{}
+	if c.DueParser != nil {
+		parser = c.DueParser
+	}
+	due, err := parser.ParseDue(text, time.Now())
+	if err != nil {
+		return err
+	}
+	if due.Date.IsZero() && len(due.String) == 0 {
+		return fmt.Errorf("invalid due date: %s", text)
+	}
+	item.Due = due
+	return nil
+}
+
 func (c *ItemClient) Delete(id ID) error {
 	command := Command{
 		Type: "item_delete",
@@ -97,25 +131,29 @@ func (c *ItemClient) Delete(id ID) error {
 
 type ItemMoveOpts struct {
 	ParentID  ID
+	SectionID ID
 	ProjectID ID
 }
 
 func (c *ItemClient) Move(id ID, opts *ItemMoveOpts) error {
-	switch len(opts.ParentID) + len(opts.ProjectID) {
-	case 0:
-		return errors.New("require parent item id or project id")
-	case 2:
-		return errors.New("require either parent item id or project id")
-	}
 	args := map[string]interface{}{
 		"id": id,
 	}
 	if len(opts.ParentID) != 0 {
 		args["parent_id"] = opts.ParentID
 	}
+	if len(opts.SectionID) != 0 {
+		args["section_id"] = opts.SectionID
+	}
 	if len(opts.ProjectID) != 0 {
 		args["project_id"] = opts.ProjectID
 	}
+	switch len(args) {
+	case 1:
+		return errors.New("require parent item id, section id or project id")
+	case 3, 4:
+		return errors.New("require only one of parent item id, section id or project id")
+	}
 
 	command := Command{
 		Type: "item_move",
@@ -126,6 +164,42 @@ func (c *ItemClient) Move(id ID, opts *ItemMoveOpts) error {
 	return nil
 }
 
+// UpdateLabels replaces the labels of the item. Unlike Update, it clears the labels with an empty slice.
+func (c *ItemClient) UpdateLabels(id ID, labels []ID) error {
+	if labels == nil {
+		labels = []ID{}
+	}
+	command := Command{
+		Type: "item_update",
+		UUID: GenerateUUID(),
+		Args: map[string]interface{}{
+			"id":     id,
+			"labels": labels,
+		},
+	}
+	c.queue = append(c.queue, command)
+	return nil
+}
+
+func (c *ItemClient) Reorder(items []Item) error {
+	var args []map[string]interface{}
+	for _, item := range items {
+		args = append(args, map[string]interface{}{
+			"id":          item.ID,
+			"child_order": item.ChildOrder,
+		})
+	}
+	command := Command{
+		Type: "item_reorder",
+		UUID: GenerateUUID(),
+		Args: map[string]interface{}{
+			"items": args,
+		},
+	}
+	c.queue = append(c.queue, command)
+	return nil
+}
+
 func (c *ItemClient) Complete(id ID, dateCompleted Time, forceHistory bool) error {
 	var fh int
 	if forceHistory {
@@ -243,25 +317,51 @@ func (c ItemClient) FindByContent(substr string) []Item {
 	return res
 }
 
+// FindByDueDate returns the items due before the time. A date without time is
+// compared as the start of the day in the local timezone.
 func (c ItemClient) FindByDueDate(time Time) []Item {
 	var res []Item
 	for _, i := range c.GetAll() {
-		if !i.Due.Date.IsZero() && i.Due.Date.Before(time) {
+		if !i.Due.Date.IsZero() && i.Due.Date.Local().Before(time) {
 			res = append(res, i)
 		}
 	}
 	return res
 }
 
+// FindByQuery returns the cached items matching the filter query.
+func (c ItemClient) FindByQuery(query string) ([]Item, error) {
+	return c.FilterByQuery(c.GetAll(), query)
+}
+
+// FilterByQuery returns the items matching the filter query.
+func (c ItemClient) FilterByQuery(items []Item, query string) ([]Item, error) {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var res []Item
+	for _, i := range items {
+		if q.match(i, c.Client, now) {
+			res = append(res, i)
+		}
+	}
+	return res, nil
+}
+
 type itemCache struct {
 	cache *[]Item
+	lazy  *lazyCache
 }
 
 func (c *itemCache) getAll() []Item {
+	c.lazy.ensure()
 	return *c.cache
 }
 
 func (c *itemCache) resolve(id ID) *Item {
+	c.lazy.ensure()
 	for _, item := range *c.cache {
 		if item.ID == id {
 			return &item
@@ -271,6 +371,7 @@ func (c *itemCache) resolve(id ID) *Item {
 }
 
 func (c *itemCache) store(item Item) {
+	c.lazy.ensure()
 	// sync api do not returns deleted items.
 	// so remove deleted items from cache too.
 	var res []Item
@@ -292,6 +393,7 @@ func (c *itemCache) store(item Item) {
 }
 
 func (c *itemCache) remove(item Item) {
+	c.lazy.ensure()
 	var res []Item
 	for _, i := range *c.cache {
 		if !i.Equal(item) {
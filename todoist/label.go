@@ -199,13 +199,16 @@ func (c LabelClient) FindOneByName(substr string) *Label {
 
 type labelCache struct {
 	cache *[]Label
+	lazy  *lazyCache
 }
 
 func (c *labelCache) getAll() []Label {
+	c.lazy.ensure()
 	return *c.cache
 }
 
 func (c *labelCache) resolve(id ID) *Label {
+	c.lazy.ensure()
 	for _, label := range *c.cache {
 		if label.ID == id {
 			return &label
@@ -215,6 +218,7 @@ func (c *labelCache) resolve(id ID) *Label {
 }
 
 func (c *labelCache) store(label Label) {
+	c.lazy.ensure()
 	var res []Label
 	isNew := true
 	for _, l := range *c.cache {
@@ -234,6 +238,7 @@ func (c *labelCache) store(label Label) {
 }
 
 func (c *labelCache) remove(label Label) {
+	c.lazy.ensure()
 	var res []Label
 	for _, l := range *c.cache {
 		if !l.Equal(label) {
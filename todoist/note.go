@@ -41,6 +41,21 @@ func NewNote(id ID, content string, opts *NewNoteOpts) (*Note, error) {
 	return &note, nil
 }
 
+// NewProjectNote returns a note attached to a project instead of an item.
+func NewProjectNote(projectID ID, content string, opts *NewNoteOpts) (*Note, error) {
+	if projectID.IsZero() || len(content) == 0 {
+		return nil, errors.New("new project note requires a project id and a content")
+	}
+	note := Note{
+		ProjectID:      projectID,
+		Content:        content,
+		FileAttachment: opts.FileAttachment,
+		UIDsToNotify:   opts.UIDsToNotify,
+	}
+	note.ID = GenerateTempID()
+	return &note, nil
+}
+
 // NoteClient encapsulate client operations for notes.
 type NoteClient struct {
 	*Client
@@ -92,11 +107,78 @@ func (c NoteClient) GetAllForItem(itemID ID) []Note {
 	return res
 }
 
+// GroupByItem returns all the cached notes by the id of their item, for lookups of many items
+// without scanning the notes for each.
+func (c NoteClient) GroupByItem() map[ID][]Note {
+	res := map[ID][]Note{}
+	for _, n := range c.cache.getAll() {
+		res[n.ItemID] = append(res[n.ItemID], n)
+	}
+	return res
+}
+
 // GetAllForProject returns all the cached notes that belong to the given project.
 func (c NoteClient) GetAllForProject(projectID ID) []Note {
+	return c.ProjectNote.GetAllForProject(projectID)
+}
+
+// ProjectNoteClient encapsulate client operations for project notes.
+// Project notes are synced as `project_notes`, apart from item notes.
+type ProjectNoteClient struct {
+	*Client
+	cache *noteCache
+}
+
+func (c ProjectNoteClient) Add(note Note) (*Note, error) {
+	if note.ProjectID.IsZero() || !note.ItemID.IsZero() {
+		return nil, errors.New("project note requires a project id and no item id")
+	}
+	c.cache.store(note)
+	command := Command{
+		Type:   "note_add",
+		Args:   note,
+		UUID:   GenerateUUID(),
+		TempID: note.ID,
+	}
+	c.queue = append(c.queue, command)
+	return &note, nil
+}
+
+func (c ProjectNoteClient) Update(note Note) (*Note, error) {
+	command := Command{
+		Type: "note_update",
+		Args: note,
+		UUID: GenerateUUID(),
+	}
+	c.queue = append(c.queue, command)
+	return &note, nil
+}
+
+func (c ProjectNoteClient) Delete(id ID) error {
+	command := Command{
+		Type: "note_delete",
+		UUID: GenerateUUID(),
+		Args: map[string]ID{
+			"id": id,
+		},
+	}
+	c.queue = append(c.queue, command)
+	return nil
+}
+
+func (c ProjectNoteClient) GetAll() []Note {
+	return c.cache.getAll()
+}
+
+func (c ProjectNoteClient) Resolve(id ID) *Note {
+	return c.cache.resolve(id)
+}
+
+// GetAllForProject returns all the cached notes that belong to the given project.
+func (c ProjectNoteClient) GetAllForProject(projectID ID) []Note {
 	var res []Note
 	for _, n := range c.cache.getAll() {
-		if n.ProjectID == projectID && n.ItemID == "" {
+		if n.ProjectID == projectID {
 			res = append(res, n)
 		}
 	}
@@ -105,13 +187,26 @@ func (c NoteClient) GetAllForProject(projectID ID) []Note {
 
 type noteCache struct {
 	cache *[]Note
+	lazy  *lazyCache
 }
 
 func (c *noteCache) getAll() []Note {
+	c.lazy.ensure()
 	return *c.cache
 }
 
+func (c *noteCache) resolve(id ID) *Note {
+	c.lazy.ensure()
+	for _, note := range *c.cache {
+		if note.ID == id {
+			return &note
+		}
+	}
+	return nil
+}
+
 func (c *noteCache) store(note Note) {
+	c.lazy.ensure()
 	var res []Note
 	isNew := true
 	for _, n := range *c.cache {
@@ -0,0 +1,101 @@
+package todoist
+
+import "context"
+
+// PageInfo is the position of a pager. Offset based listings, such as activity events and completed items,
+// advance Offset, and cursor based listings, such as archived items, advance Cursor.
+type PageInfo struct {
+	// Page is the number of fetched pages.
+	Page    int
+	Offset  int
+	Limit   int
+	Cursor  string
+	HasMore bool
+}
+
+// pageFunc fetches the page at info and returns the number of fetched entries and the next position.
+type pageFunc func(ctx context.Context, info PageInfo) (int, PageInfo, error)
+
+// pager is the common part of typed pagers, which are used like bufio.Scanner:
+//
+//	p := client.Activity.Pager(&ActivityGetOpts{})
+//	for p.Next(ctx) {
+//		events := p.Events()
+//	}
+//	if err := p.Err(); err != nil {
+//	}
+type pager struct {
+	fetch pageFunc
+	info  PageInfo
+	err   error
+}
+
+func newPager(info PageInfo, fetch pageFunc) *pager {
+	info.HasMore = true
+	return &pager{fetch: fetch, info: info}
+}
+
+// Next fetches the next page and reports whether it has any entry.
+func (p *pager) Next(ctx context.Context) bool {
+	if p.err != nil || !p.info.HasMore {
+		return false
+	}
+	n, info, err := p.fetch(ctx, p.info)
+	if err != nil {
+		p.err = err
+		p.info.HasMore = false
+		return false
+	}
+	info.Page = p.info.Page + 1
+	p.info = info
+	return n > 0
+}
+
+// Err returns the error which stopped Next.
+func (p *pager) Err() error {
+	return p.err
+}
+
+func (p *pager) PageInfo() PageInfo {
+	return p.info
+}
+
+// nextOffset advances an offset based position by n entries. A short page is the last one.
+func nextOffset(info PageInfo, n int) PageInfo {
+	info.Offset += n
+	info.HasMore = n >= info.Limit
+	return info
+}
+
+// EventPager pages through activity events.
+type EventPager struct {
+	*pager
+	events []Event
+}
+
+// Events returns the events of the current page.
+func (p *EventPager) Events() []Event {
+	return p.events
+}
+
+// CompletedPager pages through completed items.
+type CompletedPager struct {
+	*pager
+	items *CompletedItems
+}
+
+// Items returns the completed items and their projects of the current page.
+func (p *CompletedPager) Items() *CompletedItems {
+	return p.items
+}
+
+// ItemPager pages through archived items.
+type ItemPager struct {
+	*pager
+	items []Item
+}
+
+// Items returns the items of the current page.
+func (p *ItemPager) Items() []Item {
+	return p.items
+}
@@ -0,0 +1,120 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+)
+
+// PendingCommands are the commands of commits which failed to reach the server.
+// Commit keeps them in the Store of the client, so that a later run can retry them.
+type PendingCommands struct {
+	Commands []Command `json:"commands"`
+	FailedAt Time      `json:"failed_at"`
+	Error    string    `json:"error"`
+}
+
+// syncStatusError is a sync which the server answered with a status other than 2xx.
+type syncStatusError struct {
+	statusCode int
+	commands   []Command
+}
+
+func (e syncStatusError) Error() string {
+	return fmt.Sprintf("failed to sync, status code: %d, command: %v", e.statusCode, e.commands)
+}
+
+// isTransient reports whether err may succeed on a retry, such as a network error,
+// a rate limit or an error of the server.
+func isTransient(err error) bool {
+	switch e := err.(type) {
+	case *url.Error:
+		return true
+	case syncStatusError:
+		return e.statusCode == 429 || e.statusCode/100 == 5
+	}
+	return false
+}
+
+func (c *Client) pendingName() string {
+	return c.Token + ".pending"
+}
+
+// Pending returns the commands kept by failed commits, or nil when there is none.
+func (c *Client) Pending() (*PendingCommands, error) {
+	if c.Store == nil {
+		return nil, nil
+	}
+	b, err := c.Store.Get(c.pendingName())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p PendingCommands
+	if err = json.Unmarshal(b, &p); err != nil {
+		return nil, err
+	}
+	if len(p.Commands) == 0 {
+		return nil, nil
+	}
+	return &p, nil
+}
+
+// keepPending adds the commands of the failed commit to the pending commands.
+func (c *Client) keepPending(commands []Command, cause error) error {
+	if c.Store == nil {
+		return nil
+	}
+	p, err := c.Pending()
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		p = &PendingCommands{}
+	}
+	p.Commands = append(p.Commands, commands...)
+	p.FailedAt = Time{time.Now().UTC()}
+	p.Error = cause.Error()
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.Store.Put(c.pendingName(), b)
+}
+
+// RetryPending sends the pending commands again. They are kept until a retry succeeds,
+// fails with an error which is not transient, or they are discarded. The uuid of a command
+// makes the server skip a command which reached it already.
+func (c *Client) RetryPending(ctx context.Context) error {
+	p, err := c.Pending()
+	if err != nil || p == nil {
+		return err
+	}
+	if err = c.Sync(ctx, p.Commands); err != nil {
+		if err := c.DiscardPending(); err != nil {
+			return err
+		}
+		// the server rejects the commands again on every retry
+		if !isTransient(err) {
+			return err
+		}
+		if err := c.keepPending(p.Commands, err); err != nil {
+			return err
+		}
+		return err
+	}
+	return c.DiscardPending()
+}
+
+// DiscardPending drops the pending commands without sending them.
+func (c *Client) DiscardPending() error {
+	if c.Store == nil {
+		return nil
+	}
+	return c.Store.Delete(c.pendingName())
+}
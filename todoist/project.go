@@ -21,6 +21,7 @@ type Project struct {
 	IsFavorite   IntBool `json:"is_favorite"`
 	InboxProject bool    `json:"inbox_project"`
 	TeamInbox    bool    `json:"team_inbox"`
+	ViewStyle    string  `json:"view_style,omitempty"`
 }
 
 type NewProjectOpts struct {
@@ -265,13 +266,16 @@ func (c ProjectClient) FindOneByName(substr string) *Project {
 
 type projectCache struct {
 	cache *[]Project
+	lazy  *lazyCache
 }
 
 func (c *projectCache) getAll() []Project {
+	c.lazy.ensure()
 	return *c.cache
 }
 
 func (c *projectCache) resolve(id ID) *Project {
+	c.lazy.ensure()
 	for _, project := range *c.cache {
 		if project.ID == id {
 			return &project
@@ -281,6 +285,7 @@ func (c *projectCache) resolve(id ID) *Project {
 }
 
 func (c *projectCache) store(project Project) {
+	c.lazy.ensure()
 	var res []Project
 	isNew := true
 	for _, p := range *c.cache {
@@ -300,6 +305,7 @@ func (c *projectCache) store(project Project) {
 }
 
 func (c *projectCache) remove(project Project) {
+	c.lazy.ensure()
 	var res []Project
 	for _, p := range *c.cache {
 		if !p.Equal(project) {
@@ -0,0 +1,330 @@
+package todoist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a todoist filter query, such as `#Inbox & no date`, which is
+// evaluated against cached items. Only a subset of the official syntax is supported.
+type Query struct {
+	raw  string
+	root queryNode
+}
+
+type queryResolver interface {
+	resolveProject(id ID) *Project
+	resolveSection(id ID) *Section
+	resolveLabel(id ID) *Label
+	resolveUser() *User
+	resolveCollaborator(id ID) *Collaborator
+}
+
+type queryNode interface {
+	match(item Item, r queryResolver, now time.Time) bool
+}
+
+type queryAnd struct{ l, r queryNode }
+
+func (q queryAnd) match(item Item, r queryResolver, now time.Time) bool {
+	return q.l.match(item, r, now) && q.r.match(item, r, now)
+}
+
+type queryOr struct{ l, r queryNode }
+
+func (q queryOr) match(item Item, r queryResolver, now time.Time) bool {
+	return q.l.match(item, r, now) || q.r.match(item, r, now)
+}
+
+type queryNot struct{ n queryNode }
+
+func (q queryNot) match(item Item, r queryResolver, now time.Time) bool {
+	return !q.n.match(item, r, now)
+}
+
+type queryTerm func(item Item, r queryResolver, now time.Time) bool
+
+func (q queryTerm) match(item Item, r queryResolver, now time.Time) bool {
+	return q(item, r, now)
+}
+
+func ParseQuery(s string) (*Query, error) {
+	p := &queryParser{s: s}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, fmt.Errorf("unexpected %q in query: %s", p.s[p.pos], s)
+	}
+	return &Query{raw: s, root: root}, nil
+}
+
+func (q Query) String() string {
+	return q.raw
+}
+
+func (q Query) match(item Item, r queryResolver, now time.Time) bool {
+	return q.root.match(item, r, now)
+}
+
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func (p *queryParser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *queryParser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for c := p.peek(); c == '|' || c == ','; c = p.peek() {
+		p.pos++
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = queryOr{l, r}
+	}
+	return l, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == '&' {
+		p.pos++
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = queryAnd{l, r}
+	}
+	return l, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	switch p.peek() {
+	case '!':
+		p.pos++
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{n}, nil
+	case '(':
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) in query: %s", p.s)
+		}
+		p.pos++
+		return n, nil
+	}
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune("&|,()", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return parseQueryTerm(strings.TrimSpace(p.s[start:p.pos]))
+}
+
+func parseQueryTerm(term string) (queryNode, error) {
+	lower := strings.ToLower(term)
+	switch {
+	case len(term) == 0:
+		return nil, fmt.Errorf("empty term in query")
+	case strings.HasPrefix(term, "##"):
+		name := strings.ToLower(term[2:])
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			for p := r.resolveProject(item.ProjectID); p != nil; p = r.resolveProject(p.ParentID) {
+				if strings.ToLower(p.Name) == name {
+					return true
+				}
+				if p.ParentID.IsZero() {
+					break
+				}
+			}
+			return false
+		}), nil
+	case strings.HasPrefix(term, "#"):
+		name := strings.ToLower(term[1:])
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			p := r.resolveProject(item.ProjectID)
+			return p != nil && strings.ToLower(p.Name) == name
+		}), nil
+	case strings.HasPrefix(term, "/"):
+		name := strings.ToLower(term[1:])
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			s := r.resolveSection(item.SectionID)
+			return s != nil && strings.ToLower(s.Name) == name
+		}), nil
+	case strings.HasPrefix(term, "@"):
+		name := strings.ToLower(term[1:])
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			for _, id := range item.Labels {
+				if l := r.resolveLabel(id); l != nil && strings.ToLower(l.Name) == name {
+					return true
+				}
+			}
+			return false
+		}), nil
+	case lower == "p1" || lower == "p2" || lower == "p3" || lower == "p4":
+		// p1 is the highest priority, which is 4 in the api.
+		priority := 5 - int(lower[1]-'0')
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			p := item.Priority
+			if p == 0 {
+				p = 1
+			}
+			return p == priority
+		}), nil
+	case lower == "no labels":
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return len(item.Labels) == 0
+		}), nil
+	case lower == "no section":
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return item.SectionID.IsZero()
+		}), nil
+	case lower == "no date" || lower == "no due date":
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return item.Due.Date.IsZero()
+		}), nil
+	case lower == "recurring":
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return item.Due.IsRecurring
+		}), nil
+	case lower == "subtask":
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return !item.ParentID.IsZero()
+		}), nil
+	case lower == "overdue" || lower == "od":
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return item.Due.Date.isOverdue(now)
+		}), nil
+	case lower == "today" || lower == "tomorrow":
+		offset := 0
+		if lower == "tomorrow" {
+			offset = 1
+		}
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			day := Time{endOfDay(now, now.Location()).AddDate(0, 0, offset)}
+			return !item.Due.Date.IsZero() && item.Due.Date.EndOfDay(now.Location()).Equal(day)
+		}), nil
+	case strings.HasSuffix(lower, " days"):
+		// `7 days` or `next 7 days`: due from today through the next n-1 days.
+		n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(strings.TrimSuffix(lower, " days"), "next")))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("unsupported filter term: %s", term)
+		}
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			if item.Due.Date.IsZero() {
+				return false
+			}
+			d := item.Due.Date.EndOfDay(now.Location())
+			today := endOfDay(now, now.Location())
+			return !d.Before(today) && d.Before(Time{today.AddDate(0, 0, n)})
+		}), nil
+	case strings.HasPrefix(lower, "due before:") || strings.HasPrefix(lower, "due after:") || strings.HasPrefix(lower, "date:"):
+		i := strings.Index(term, ":")
+		date, err := time.Parse(dateLayout, strings.TrimSpace(term[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date in query term: %s", term)
+		}
+		op := strings.TrimSpace(lower[:i])
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			if item.Due.Date.IsZero() {
+				return false
+			}
+			d := Time{date}.EndOfDay(now.Location())
+			due := item.Due.Date.EndOfDay(now.Location())
+			switch op {
+			case "due before":
+				return due.Before(d)
+			case "due after":
+				return due.After(d)
+			default:
+				return due.Equal(d)
+			}
+		}), nil
+	case lower == "assigned":
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return !item.ResponsibleUID.IsZero()
+		}), nil
+	case strings.HasPrefix(lower, "assigned to:") || strings.HasPrefix(lower, "assigned by:"):
+		i := strings.Index(term, ":")
+		who := strings.ToLower(strings.TrimSpace(term[i+1:]))
+		if len(who) == 0 {
+			return nil, fmt.Errorf("require a person in query term: %s", term)
+		}
+		by := strings.HasPrefix(lower, "assigned by:")
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			uid := item.ResponsibleUID
+			if by {
+				uid = item.AssignedByUID
+			}
+			if uid.IsZero() {
+				return false
+			}
+			user := r.resolveUser()
+			switch who {
+			case "me":
+				return user != nil && uid == user.ID
+			case "others":
+				return user == nil || uid != user.ID
+			}
+			if c := r.resolveCollaborator(uid); c != nil {
+				return strings.ToLower(c.Email) == who || strings.Contains(strings.ToLower(c.FullName), who)
+			}
+			return false
+		}), nil
+	case strings.HasPrefix(lower, "search:"):
+		substr := strings.ToLower(strings.TrimSpace(term[len("search:"):]))
+		return queryTerm(func(item Item, r queryResolver, now time.Time) bool {
+			return strings.Contains(strings.ToLower(item.Content), substr)
+		}), nil
+	}
+	return nil, fmt.Errorf("unsupported filter term: %s", term)
+}
+
+func (c *Client) resolveProject(id ID) *Project {
+	return c.Project.Resolve(id)
+}
+
+func (c *Client) resolveSection(id ID) *Section {
+	return c.Section.Resolve(id)
+}
+
+func (c *Client) resolveLabel(id ID) *Label {
+	return c.Label.Resolve(id)
+}
+
+func (c *Client) resolveUser() *User {
+	return c.User.Get()
+}
+
+func (c *Client) resolveCollaborator(id ID) *Collaborator {
+	return c.Collaborator.Resolve(id)
+}
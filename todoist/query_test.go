@@ -0,0 +1,132 @@
+package todoist
+
+import (
+	"testing"
+	"time"
+)
+
+type testQueryResolver struct {
+	projects      map[ID]Project
+	sections      map[ID]Section
+	labels        map[ID]Label
+	user          *User
+	collaborators map[ID]Collaborator
+}
+
+func (r testQueryResolver) resolveProject(id ID) *Project {
+	if p, ok := r.projects[id]; ok {
+		return &p
+	}
+	return nil
+}
+
+func (r testQueryResolver) resolveSection(id ID) *Section {
+	if s, ok := r.sections[id]; ok {
+		return &s
+	}
+	return nil
+}
+
+func (r testQueryResolver) resolveLabel(id ID) *Label {
+	if l, ok := r.labels[id]; ok {
+		return &l
+	}
+	return nil
+}
+
+func (r testQueryResolver) resolveUser() *User {
+	return r.user
+}
+
+func (r testQueryResolver) resolveCollaborator(id ID) *Collaborator {
+	if c, ok := r.collaborators[id]; ok {
+		return &c
+	}
+	return nil
+}
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2019, 3, 10, 12, 0, 0, 0, time.Local)
+	r := testQueryResolver{
+		projects: map[ID]Project{
+			"1": {Entity: Entity{ID: "1"}, Name: "Inbox"},
+			"2": {Entity: Entity{ID: "2"}, Name: "Work"},
+			"3": {Entity: Entity{ID: "3"}, Name: "Meetings", ParentID: "2"},
+		},
+		sections: map[ID]Section{
+			"10": {Entity: Entity{ID: "10"}, Name: "Triage", ProjectID: "2"},
+		},
+		labels: map[ID]Label{
+			"20": {Entity: Entity{ID: "20"}, Name: "deep"},
+		},
+		user: &User{ID: "30"},
+		collaborators: map[ID]Collaborator{
+			"30": {ID: "30", FullName: "Me"},
+			"31": {ID: "31", FullName: "Alice Smith", Email: "alice@example.com"},
+		},
+	}
+	inbox := Item{ProjectID: "1", Content: "buy milk"}
+	work := Item{ProjectID: "2", SectionID: "10", Labels: []ID{"20"}, Priority: 4}
+	work.Due.Date = Time{time.Date(2019, 3, 10, 0, 0, 0, 0, time.UTC)}
+	work.ResponsibleUID = "30"
+	meeting := Item{ProjectID: "3", ParentID: "100", ResponsibleUID: "31", AssignedByUID: "30"}
+	meeting.Due.Date = Time{time.Date(2019, 3, 9, 0, 0, 0, 0, time.UTC)}
+	items := []Item{inbox, work, meeting}
+
+	tests := []struct {
+		query  string
+		expect []bool
+	}{
+		{"#Inbox & no date", []bool{true, false, false}},
+		{"#inbox", []bool{true, false, false}},
+		{"##Work", []bool{false, true, true}},
+		{"/Triage", []bool{false, true, false}},
+		{"@deep", []bool{false, true, false}},
+		{"no labels", []bool{true, false, true}},
+		{"p1", []bool{false, true, false}},
+		{"p4", []bool{true, false, true}},
+		{"today", []bool{false, true, false}},
+		{"overdue", []bool{false, false, true}},
+		{"today | overdue", []bool{false, true, true}},
+		{"!(today | overdue)", []bool{true, false, false}},
+		{"7 days", []bool{false, true, false}},
+		{"subtask", []bool{false, false, true}},
+		{"search: milk", []bool{true, false, false}},
+		{"due before: 2019-03-10", []bool{false, false, true}},
+		{"assigned", []bool{false, true, true}},
+		{"assigned to: me", []bool{false, true, false}},
+		{"assigned to: others", []bool{false, false, true}},
+		{"assigned to: alice", []bool{false, false, true}},
+		{"assigned by: me", []bool{false, false, true}},
+	}
+	for _, test := range tests {
+		q, err := ParseQuery(test.query)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", test.query, err)
+			continue
+		}
+		for i, item := range items {
+			if got := q.match(item, r, now); got != test.expect[i] {
+				t.Errorf("%q: item %d: expect %v, but got %v", test.query, i, test.expect[i], got)
+			}
+		}
+	}
+
+	// near midnight, a date without time is due on its calendar day of the local timezone
+	newYork := time.FixedZone("EST", -5*60*60)
+	midnight := time.Date(2019, 3, 10, 23, 30, 0, 0, newYork)
+	var allDay Item
+	allDay.Due.Date = Time{time.Date(2019, 3, 11, 0, 0, 0, 0, time.UTC)}
+	for query, expect := range map[string]bool{"today": false, "tomorrow": true, "overdue": false} {
+		q, _ := ParseQuery(query)
+		if got := q.match(allDay, r, midnight); got != expect {
+			t.Errorf("%q near midnight: expect %v, but got %v", query, expect, got)
+		}
+	}
+
+	for _, query := range []string{"", "#Inbox &", "(today", "unknown term", "today)", "assigned to:"} {
+		if _, err := ParseQuery(query); err == nil {
+			t.Errorf("%q: expect error, but no error", query)
+		}
+	}
+}
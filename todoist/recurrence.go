@@ -0,0 +1,95 @@
+package todoist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is the interval of a recurring due date, such as `every 2 weeks`.
+type Recurrence struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+var weekdays = []string{
+	"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday",
+	"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+}
+
+// ParseRecurrence parses the interval of the english due string of a recurring item.
+// Irregular ones, such as `every mon, fri` or `every workday`, are not supported.
+func ParseRecurrence(s string) (Recurrence, error) {
+	str := strings.ToLower(strings.TrimSpace(s))
+	// the time of the day and the start do not change the interval
+	for _, sep := range []string{" at ", " starting ", " from ", " until ", " ending "} {
+		if i := strings.Index(str, sep); i >= 0 {
+			str = str[:i]
+		}
+	}
+	switch str {
+	case "daily":
+		return Recurrence{Days: 1}, nil
+	case "weekly":
+		return Recurrence{Days: 7}, nil
+	case "monthly":
+		return Recurrence{Months: 1}, nil
+	case "yearly", "annually":
+		return Recurrence{Years: 1}, nil
+	}
+	fields := strings.Fields(str)
+	if len(fields) < 2 || (fields[0] != "every" && fields[0] != "every!" && fields[0] != "ev" && fields[0] != "ev!") {
+		return Recurrence{}, fmt.Errorf("unsupported recurrence: %s", s)
+	}
+	fields = fields[1:]
+	n := 1
+	if fields[0] == "other" {
+		n, fields = 2, fields[1:]
+	} else if v, err := strconv.Atoi(fields[0]); err == nil && len(fields) > 1 {
+		n, fields = v, fields[1:]
+	}
+	if len(fields) != 1 || n <= 0 {
+		return Recurrence{}, fmt.Errorf("unsupported recurrence: %s", s)
+	}
+	switch unit := strings.TrimSuffix(fields[0], "s"); unit {
+	case "day":
+		return Recurrence{Days: n}, nil
+	case "week":
+		return Recurrence{Days: 7 * n}, nil
+	case "month":
+		return Recurrence{Months: n}, nil
+	case "year":
+		return Recurrence{Years: n}, nil
+	}
+	for _, w := range weekdays {
+		if fields[0] == w {
+			return Recurrence{Days: 7 * n}, nil
+		}
+	}
+	// a day of the month, such as `every 15th`
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if d, err := strconv.Atoi(strings.TrimSuffix(fields[0], suffix)); err == nil && strings.HasSuffix(fields[0], suffix) && d >= 1 && d <= 31 {
+			return Recurrence{Months: n}, nil
+		}
+	}
+	return Recurrence{}, fmt.Errorf("unsupported recurrence: %s", s)
+}
+
+// Before returns the occurrence n intervals before t.
+func (r Recurrence) Before(t time.Time, n int) time.Time {
+	return t.AddDate(-r.Years*n, -r.Months*n, -r.Days*n)
+}
+
+func (r Recurrence) String() string {
+	switch {
+	case r.Years != 0:
+		return strconv.Itoa(r.Years) + "y"
+	case r.Months != 0:
+		return strconv.Itoa(r.Months) + "m"
+	case r.Days%7 == 0:
+		return strconv.Itoa(r.Days/7) + "w"
+	}
+	return strconv.Itoa(r.Days) + "d"
+}
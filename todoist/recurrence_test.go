@@ -0,0 +1,30 @@
+package todoist
+
+import "testing"
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		s      string
+		expect Recurrence
+	}{
+		{"every day", Recurrence{Days: 1}},
+		{"Daily", Recurrence{Days: 1}},
+		{"every! 3 days", Recurrence{Days: 3}},
+		{"every other week", Recurrence{Days: 14}},
+		{"every monday at 10am", Recurrence{Days: 7}},
+		{"every 2 months starting 2019-03-01", Recurrence{Months: 2}},
+		{"every 15th", Recurrence{Months: 1}},
+		{"every year", Recurrence{Years: 1}},
+	}
+	for _, test := range tests {
+		r, err := ParseRecurrence(test.s)
+		if err != nil || r != test.expect {
+			t.Errorf("%q: expect %v, but got %v (%v)", test.s, test.expect, r, err)
+		}
+	}
+	for _, s := range []string{"", "tomorrow", "every mon, fri", "every workday", "every 0 days", "every 32nd"} {
+		if _, err := ParseRecurrence(s); err == nil {
+			t.Errorf("%q: expect error, but no error", s)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package todoist
+
+import (
+	"regexp"
+)
+
+// referencePattern matches a reference to an item in a text, written as
+// `todoist://item/ID` or `[[ID]]`, or a link to the item of the todoist apps, such as
+// `https://todoist.com/showTask?id=ID` or `todoist://task?id=ID`.
+var referencePattern = regexp.MustCompile(`todoist://item/([0-9a-fA-F-]+)|\[\[([0-9a-fA-F-]+)\]\]|` +
+	`(?:(?:https?://(?:app\.)?todoist\.com/)?showTask\?id=|todoist://task\?id=)([0-9a-fA-F-]+)`)
+
+// ParseReferences returns the ids of the items referenced in the text, in order
+// of appearance without duplicates.
+func ParseReferences(text string) []ID {
+	var res []ID
+	seen := map[ID]bool{}
+	for _, m := range referencePattern.FindAllStringSubmatch(text, -1) {
+		id := referenceID(m)
+		if !IsValidID(id) || seen[id] {
+			continue
+		}
+		seen[id] = true
+		res = append(res, id)
+	}
+	return res
+}
+
+// ExpandReferences replaces each reference in the text with the result of f.
+// A reference is kept as is when f returns an empty string.
+func ExpandReferences(text string, f func(id ID) string) string {
+	return referencePattern.ReplaceAllStringFunc(text, func(s string) string {
+		id := referenceID(referencePattern.FindStringSubmatch(s))
+		if !IsValidID(id) {
+			return s
+		}
+		if r := f(id); len(r) != 0 {
+			return r
+		}
+		return s
+	})
+}
+
+func referenceID(m []string) ID {
+	for _, id := range m[1:] {
+		if len(id) != 0 {
+			return ID(id)
+		}
+	}
+	return ""
+}
+
+// References returns the ids of the items referenced by the content and the notes of the item.
+func (c ItemClient) References(id ID) []ID {
+	item := c.Resolve(id)
+	if item == nil {
+		return nil
+	}
+	text := item.Content
+	for _, n := range c.Note.GetAllForItem(id) {
+		text += "\n" + n.Content
+	}
+	var res []ID
+	for _, ref := range ParseReferences(text) {
+		if ref != id {
+			res = append(res, ref)
+		}
+	}
+	return res
+}
+
+// Backlinks returns the cached items whose content or notes reference the item.
+func (c ItemClient) Backlinks(id ID) []Item {
+	linked := map[ID]bool{}
+	for _, i := range c.GetAll() {
+		if i.ID != id && referencesID(i.Content, id) {
+			linked[i.ID] = true
+		}
+	}
+	for _, n := range c.Note.cache.getAll() {
+		if n.ItemID != id && referencesID(n.Content, id) {
+			linked[n.ItemID] = true
+		}
+	}
+	var res []Item
+	for _, i := range c.GetAll() {
+		if linked[i.ID] {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func referencesID(text string, id ID) bool {
+	for _, ref := range ParseReferences(text) {
+		if ref == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package todoist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReferences(t *testing.T) {
+	tests := []struct {
+		s      string
+		expect []ID
+	}{
+		{"no reference", nil},
+		{"see todoist://item/123", []ID{"123"}},
+		{"after [[123]] and [[456]], again [[123]]", []ID{"123", "456"}},
+		{"todoist://item/123 [[fa3fc018-876d-4a5b-9e06-c1b6d2bcc0f3]]", []ID{"123", "fa3fc018-876d-4a5b-9e06-c1b6d2bcc0f3"}},
+		{"[[not an id]] [[12ab]] [[ 123 ]]", nil},
+		{"after https://todoist.com/showTask?id=123 and todoist://task?id=456", []ID{"123", "456"}},
+	}
+	for _, test := range tests {
+		if got := ParseReferences(test.s); !reflect.DeepEqual(got, test.expect) {
+			t.Errorf("%q: expect %v, but got %v", test.s, test.expect, got)
+		}
+	}
+}
+
+func TestExpandReferences(t *testing.T) {
+	f := func(id ID) string {
+		if id == "123" {
+			return "buy milk"
+		}
+		return ""
+	}
+	s := ExpandReferences("after [[123]], see todoist://item/123, https://todoist.com/showTask?id=123 and [[456]]", f)
+	if expect := "after buy milk, see buy milk, buy milk and [[456]]"; s != expect {
+		t.Errorf("expect %q, but got %q", expect, s)
+	}
+}
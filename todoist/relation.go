@@ -7,11 +7,12 @@ type RelationClient struct {
 type ItemRelations struct {
 	//Users map[ID]User
 	Projects map[ID]Project
+	Sections map[ID]Section
 	Labels   map[ID]Label
 }
 
 func (c RelationClient) Items(items []Item) ItemRelations {
-	res := ItemRelations{Projects: map[ID]Project{}, Labels: map[ID]Label{}}
+	res := ItemRelations{Projects: map[ID]Project{}, Sections: map[ID]Section{}, Labels: map[ID]Label{}}
 	for _, item := range items {
 		if _, ok := res.Projects[item.ProjectID]; !ok {
 			p := c.Project.Resolve(item.ProjectID)
@@ -19,6 +20,12 @@ func (c RelationClient) Items(items []Item) ItemRelations {
 				res.Projects[item.ProjectID] = *p
 			}
 		}
+		if _, ok := res.Sections[item.SectionID]; !ok && !item.SectionID.IsZero() {
+			s := c.Section.Resolve(item.SectionID)
+			if s != nil {
+				res.Sections[item.SectionID] = *s
+			}
+		}
 		for _, id := range item.Labels {
 			if _, ok := res.Labels[id]; !ok {
 				l := c.Label.Resolve(id)
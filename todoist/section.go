@@ -0,0 +1,174 @@
+package todoist
+
+import (
+	"errors"
+	"strings"
+)
+
+type Section struct {
+	Entity
+	Name         string `json:"name"`
+	ProjectID    ID     `json:"project_id"`
+	SectionOrder int    `json:"section_order"`
+	Collapsed    bool   `json:"collapsed"`
+	UserID       ID     `json:"user_id,omitempty"`
+	SyncID       ID     `json:"sync_id,omitempty"`
+	IsArchived   bool   `json:"is_archived"`
+	DateArchived Time   `json:"date_archived"`
+	DateAdded    Time   `json:"date_added"`
+}
+
+func NewSection(name string, projectID ID) (*Section, error) {
+	if len(name) == 0 || projectID.IsZero() {
+		return nil, errors.New("new section requires a name and a project id")
+	}
+	section := Section{
+		Name:      name,
+		ProjectID: projectID,
+	}
+	section.ID = GenerateTempID()
+	return &section, nil
+}
+
+func (s Section) String() string {
+	return "/" + s.Name
+}
+
+func (s Section) ColorString() string {
+	return s.String()
+}
+
+type SectionClient struct {
+	*Client
+	cache *sectionCache
+}
+
+func (c *SectionClient) Add(section Section) (*Section, error) {
+	c.cache.store(section)
+	command := Command{
+		Type:   "section_add",
+		Args:   section,
+		UUID:   GenerateUUID(),
+		TempID: section.ID,
+	}
+	c.queue = append(c.queue, command)
+	return &section, nil
+}
+
+func (c *SectionClient) Update(section Section) (*Section, error) {
+	command := Command{
+		Type: "section_update",
+		Args: map[string]interface{}{
+			"id":        section.ID,
+			"name":      section.Name,
+			"collapsed": section.Collapsed,
+		},
+		UUID: GenerateUUID(),
+	}
+	c.queue = append(c.queue, command)
+	return &section, nil
+}
+
+func (c *SectionClient) Move(id, projectID ID) error {
+	command := Command{
+		Type: "section_move",
+		UUID: GenerateUUID(),
+		Args: map[string]ID{
+			"id":         id,
+			"project_id": projectID,
+		},
+	}
+	c.queue = append(c.queue, command)
+	return nil
+}
+
+func (c *SectionClient) Delete(id ID) error {
+	command := Command{
+		Type: "section_delete",
+		UUID: GenerateUUID(),
+		Args: map[string]ID{
+			"id": id,
+		},
+	}
+	c.queue = append(c.queue, command)
+	return nil
+}
+
+func (c *SectionClient) GetAll() []Section {
+	return c.cache.getAll()
+}
+
+func (c *SectionClient) Resolve(id ID) *Section {
+	return c.cache.resolve(id)
+}
+
+func (c SectionClient) FindByProjectID(projectID ID) []Section {
+	var res []Section
+	for _, s := range c.GetAll() {
+		if s.ProjectID == projectID {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// FindOneByName returns the section of the project whose name matches substr,
+// preferring an exact match.
+func (c SectionClient) FindOneByName(projectID ID, substr string) *Section {
+	if r := []rune(substr); len(r) > 0 && string(r[0]) == "/" {
+		substr = string(r[1:])
+	}
+	var candidates []Section
+	for _, s := range c.FindByProjectID(projectID) {
+		if s.Name == substr {
+			return &s
+		}
+		if strings.Contains(s.Name, substr) {
+			candidates = append(candidates, s)
+		}
+	}
+	if len(candidates) > 0 {
+		return &candidates[0]
+	}
+	return nil
+}
+
+type sectionCache struct {
+	cache *[]Section
+	lazy  *lazyCache
+}
+
+func (c *sectionCache) getAll() []Section {
+	c.lazy.ensure()
+	return *c.cache
+}
+
+func (c *sectionCache) resolve(id ID) *Section {
+	c.lazy.ensure()
+	for _, section := range *c.cache {
+		if section.ID == id {
+			return &section
+		}
+	}
+	return nil
+}
+
+func (c *sectionCache) store(section Section) {
+	c.lazy.ensure()
+	var res []Section
+	isNew := true
+	for _, s := range *c.cache {
+		if s.Equal(section) {
+			if !section.IsDeleted {
+				res = append(res, section)
+			}
+			isNew = false
+		} else {
+			res = append(res, s)
+		}
+	}
+	if isNew && !section.IsDeleted.Bool() {
+		res = append(res, section)
+	}
+	c.cache = &res
+}
@@ -0,0 +1,110 @@
+package todoist
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Store keeps the durable state of a client by name, such as the pending commands.
+// Get reports an error satisfying os.IsNotExist when nothing is stored under the name.
+type Store interface {
+	Get(name string) ([]byte, error)
+	Put(name string, b []byte) error
+	Delete(name string) error
+}
+
+// FileStore keeps each state as a json file in the directory. The files are readable by the
+// owner only, as states such as the pending commands contain the content of items.
+type FileStore struct {
+	Dir string
+}
+
+func (s FileStore) path(name string) string {
+	return filepath.Join(s.Dir, name+".json")
+}
+
+func (s FileStore) Get(name string) ([]byte, error) {
+	return ioutil.ReadFile(s.path(name))
+}
+
+func (s FileStore) Put(name string, b []byte) error {
+	if err := ioutil.WriteFile(s.path(name), b, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of a file written before
+	return os.Chmod(s.path(name), 0600)
+}
+
+func (s FileStore) Delete(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// SQLStore keeps the states as rows of a table in a database, such as SQLite.
+// The database is opened with the driver of choice, which the caller imports.
+// The queries use ? placeholders and a BLOB column, so drivers such as sqlite3 and mysql work,
+// but not the ones with numbered placeholders such as postgres.
+type SQLStore struct {
+	DB    *sql.DB
+	Table string
+}
+
+var sqlTablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NewSQLStore creates the table of the store unless it exists. The table is
+// todoist_store when it is empty.
+func NewSQLStore(db *sql.DB, table string) (*SQLStore, error) {
+	if len(table) == 0 {
+		table = "todoist_store"
+	}
+	if !sqlTablePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %s", table)
+	}
+	if _, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+  name VARCHAR(255) PRIMARY KEY,
+  value BLOB NOT NULL,
+  updated_at TIMESTAMP NOT NULL
+)`, table)); err != nil {
+		return nil, err
+	}
+	return &SQLStore{DB: db, Table: table}, nil
+}
+
+func (s SQLStore) Get(name string) ([]byte, error) {
+	var b []byte
+	err := s.DB.QueryRow(fmt.Sprintf("SELECT value FROM %s WHERE name = ?", s.Table), name).Scan(&b)
+	if err == sql.ErrNoRows {
+		return nil, os.ErrNotExist
+	}
+	return b, err
+}
+
+// Put replaces the state by a delete and an insert in a transaction, as the upsert syntax differs
+// between sqlite3 and mysql.
+func (s SQLStore) Put(name string, b []byte) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE name = ?", s.Table), name); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err = tx.Exec(fmt.Sprintf("INSERT INTO %s (name, value, updated_at) VALUES (?, ?, ?)", s.Table), name, b, time.Now().UTC()); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s SQLStore) Delete(name string) error {
+	_, err := s.DB.Exec(fmt.Sprintf("DELETE FROM %s WHERE name = ?", s.Table), name)
+	return err
+}
@@ -0,0 +1,57 @@
+//go:build sqlite
+// +build sqlite
+
+package todoist
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSQLStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "todoist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	s, err := NewSQLStore(db, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Get("state"); !os.IsNotExist(err) {
+		t.Errorf("expect not exist error, but got %v", err)
+	}
+	if err = s.Put("state", []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	// put replaces the state
+	if err = s.Put("state", []byte(`{"a":2}`)); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := s.Get("state"); err != nil || string(b) != `{"a":2}` {
+		t.Errorf("expect the replaced state, but got %s (%v)", b, err)
+	}
+	// the table exists already on the next open
+	if s, err = NewSQLStore(db, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Delete("state"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Get("state"); !os.IsNotExist(err) {
+		t.Errorf("expect not exist error after delete, but got %v", err)
+	}
+	if err = s.Delete("state"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
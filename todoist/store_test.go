@@ -0,0 +1,47 @@
+package todoist
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "todoist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := FileStore{Dir: dir}
+	if _, err = s.Get("state"); !os.IsNotExist(err) {
+		t.Errorf("expect not exist error, but got %v", err)
+	}
+	if err = s.Put("state", []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := s.Get("state"); err != nil || string(b) != `{"a":1}` {
+		t.Errorf("expect the stored state, but got %s (%v)", b, err)
+	}
+	if fi, err := os.Stat(s.path("state")); err != nil || (runtime.GOOS != "windows" && fi.Mode().Perm() != 0600) {
+		t.Errorf("expect the state readable by the owner only, but got %v (%v)", fi.Mode(), err)
+	}
+	if err = s.Delete("state"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Get("state"); !os.IsNotExist(err) {
+		t.Errorf("expect not exist error after delete, but got %v", err)
+	}
+	// deleting nothing is not an error
+	if err = s.Delete("state"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestNewSQLStore(t *testing.T) {
+	for _, table := range []string{"todoist store", "store; DROP TABLE items", "1store"} {
+		if _, err := NewSQLStore(nil, table); err == nil {
+			t.Errorf("%q: expect error, but no error", table)
+		}
+	}
+}
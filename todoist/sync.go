@@ -1,9 +1,11 @@
 package todoist
 
+import "encoding/json"
+
 type SyncState struct {
-	SyncToken string `json:"sync_token"`
-	FullSync  bool   `json:"full_sync"`
-	// User User `json:"user"`
+	SyncToken    string    `json:"sync_token"`
+	FullSync     bool      `json:"full_sync"`
+	User         User      `json:"user"`
 	Projects     []Project `json:"projects"`
 	ProjectNotes []Note    `json:"project_notes"`
 	Items        []Item    `json:"items"`
@@ -12,13 +14,16 @@ type SyncState struct {
 	Filters      []Filter  `json:"filters"`
 	// DayOrders struct {} `json:"day_orders"`
 	// DayOrdersTimestamp string `json:"day_orders_timestamp"`
-	Reminders []Reminder `json:"reminders"`
-	// Collaborators []interface{} `json:"collaborators"`
-	// CollaboratorStates []CollaboratorState `json:"collaborator_states"`
+	Reminders          []Reminder          `json:"reminders"`
+	Sections           []Section           `json:"sections"`
+	Collaborators      []Collaborator      `json:"collaborators"`
+	CollaboratorStates []CollaboratorState `json:"collaborator_states"`
 	// LiveNotifications []LiveNotification `json:"live_notifications"`
 	// LiveNotificationsLastReadID int `json:"live_notifications_last_read_id"`
 	// Locations []interface{} `json:"locations"`
-	// TempIDMapping struct {} `json:"temp_id_mapping"`
+	TempIDMapping map[ID]ID `json:"temp_id_mapping,omitempty"`
+	// SyncStatus is the result of each command by its uuid, `"ok"` or an error object.
+	SyncStatus map[UUID]json.RawMessage `json:"sync_status,omitempty"`
 }
 
 type Command struct {
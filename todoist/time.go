@@ -16,16 +16,14 @@ type Time struct {
 	time.Time
 }
 
+// Today returns the end of today in the local timezone.
 func Today() Time {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 1, time.Local)
-	return Time{today.UTC()}
+	return Time{endOfDay(time.Now(), time.Local).UTC()}
 }
 
+// Next7Days returns the end of the 7th day from today, counting today, in the local timezone.
 func Next7Days() Time {
-	d := time.Now().Add(6 * 24 * time.Hour)
-	days := time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 59, 1, time.Local)
-	return Time{days.UTC()}
+	return Time{endOfDay(time.Now().AddDate(0, 0, 6), time.Local).UTC()}
 }
 
 func Parse(value string) (Time, error) {
@@ -52,7 +50,46 @@ func (t Time) After(u Time) bool {
 }
 
 func (t Time) Local() Time {
-	return Time{t.Time.Local()}
+	return t.In(time.Local)
+}
+
+// DateOnly reports whether t is a date without time, such as a due date of all day.
+func (t Time) DateOnly() bool {
+	u := t.Time.UTC()
+	return u.Hour() == 0 && u.Minute() == 0 && u.Second() == 0 && u.Nanosecond() == 0
+}
+
+// In returns t in loc. A date without time stays on the same calendar day in every location.
+func (t Time) In(loc *time.Location) Time {
+	if t.IsZero() {
+		return t
+	}
+	if t.DateOnly() {
+		u := t.Time.UTC()
+		return Time{time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, loc)}
+	}
+	return Time{t.Time.In(loc)}
+}
+
+// EndOfDay returns the last moment of the calendar day of t in loc.
+func (t Time) EndOfDay(loc *time.Location) Time {
+	return endOfDay(t.In(loc).Time, loc)
+}
+
+func endOfDay(t time.Time, loc *time.Location) Time {
+	l := t.In(loc)
+	return Time{time.Date(l.Year(), l.Month(), l.Day(), 23, 59, 59, int(time.Second-1), loc)}
+}
+
+// isOverdue reports whether t has passed at now. A date without time passes at the end of the day.
+func (t Time) isOverdue(now time.Time) bool {
+	if t.IsZero() {
+		return false
+	}
+	if t.DateOnly() {
+		return t.EndOfDay(now.Location()).Before(Time{now})
+	}
+	return t.Before(Time{now})
 }
 
 func (t Time) MarshalJSON() ([]byte, error) {
@@ -83,11 +120,11 @@ func (t Time) String() string {
 	if t.IsZero() {
 		return ""
 	}
-	return t.Time.Local().Format(localLayout)
+	return t.In(time.Local).Format(localLayout)
 }
 
 func (t Time) ColorString() string {
-	if !t.IsZero() && t.Before(Time{time.Now()}) {
+	if t.isOverdue(time.Now()) {
 		return color.New(color.BgRed).Sprint(t.String())
 	}
 	return t.String()
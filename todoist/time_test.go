@@ -88,3 +88,52 @@ func TestTimeJson(t *testing.T) {
 		}
 	}
 }
+
+func TestTime_In(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	newYork := time.FixedZone("EST", -5*60*60)
+	date := Time{time.Date(2019, 3, 10, 0, 0, 0, 0, time.UTC)}
+	datetime := Time{time.Date(2019, 3, 10, 20, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		t      Time
+		loc    *time.Location
+		expect string
+	}{
+		{date, tokyo, "2019-03-10 00:00"},
+		{date, newYork, "2019-03-10 00:00"},
+		{datetime, tokyo, "2019-03-11 05:00"},
+		{datetime, newYork, "2019-03-10 15:00"},
+	}
+	for _, test := range tests {
+		if got := test.t.In(test.loc).Format("2006-01-02 15:04"); got != test.expect {
+			t.Errorf("%s in %s: expect %s, but got %s", test.t.Time, test.loc, test.expect, got)
+		}
+	}
+	if !date.DateOnly() || datetime.DateOnly() {
+		t.Errorf("unexpected DateOnly")
+	}
+	if got := datetime.EndOfDay(tokyo).Format(time.RFC3339Nano); got != "2019-03-11T23:59:59.999999999+09:00" {
+		t.Errorf("unexpected end of day: %s", got)
+	}
+}
+
+func TestTime_isOverdue(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*60*60)
+	// 23:30 of 2019-03-10 in New York, which is already 2019-03-11 in UTC
+	now := time.Date(2019, 3, 10, 23, 30, 0, 0, newYork)
+	tests := []struct {
+		t      Time
+		expect bool
+	}{
+		{Time{time.Date(2019, 3, 10, 0, 0, 0, 0, time.UTC)}, false},
+		{Time{time.Date(2019, 3, 9, 0, 0, 0, 0, time.UTC)}, true},
+		{Time{time.Date(2019, 3, 11, 4, 0, 0, 0, time.UTC)}, true},
+		{Time{time.Date(2019, 3, 11, 5, 0, 0, 0, time.UTC)}, false},
+		{Time{}, false},
+	}
+	for _, test := range tests {
+		if got := test.t.isOverdue(now); got != test.expect {
+			t.Errorf("%s: expect %v, but got %v", test.t.Time, test.expect, got)
+		}
+	}
+}
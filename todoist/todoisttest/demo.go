@@ -0,0 +1,170 @@
+package todoisttest
+
+import (
+	"github.com/kobtea/go-todoist/todoist"
+	"strconv"
+	"time"
+)
+
+// noDue marks the demo items without a due date.
+const noDue = -1 << 16
+
+// DemoState returns a small account with projects, labels, filters and items.
+// Ids and contents are fixed, and due dates are relative to the day of now,
+// so that the same commands show the same output on every day.
+func DemoState(now time.Time) todoist.SyncState {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := func(n int) todoist.Time { return todoist.Time{Time: today.AddDate(0, 0, n)} }
+	entity := func(id todoist.ID) todoist.Entity { return todoist.Entity{ID: id} }
+
+	state := todoist.SyncState{
+		User: todoist.User{ID: "1", Email: "demo@example.com", FullName: "Demo User", InboxProjectID: "100"},
+		Projects: []todoist.Project{
+			{Entity: entity("100"), Name: "Inbox", Color: 48, InboxProject: true},
+			{Entity: entity("101"), Name: "Work", Color: 31, ChildOrder: 1},
+			{Entity: entity("102"), Name: "Meetings", Color: 33, ChildOrder: 1, ParentID: "101"},
+			{Entity: entity("103"), Name: "Home", Color: 36, ChildOrder: 2},
+			{Entity: entity("104"), Name: "Someday", Color: 47, ChildOrder: 3},
+		},
+		Sections: []todoist.Section{
+			{Entity: entity("200"), Name: "Triage", ProjectID: "101", SectionOrder: 1},
+			{Entity: entity("201"), Name: "In progress", ProjectID: "101", SectionOrder: 2},
+		},
+		Labels: []todoist.Label{
+			{Entity: entity("300"), Name: "deep", Color: 40, ItemOrder: 1},
+			{Entity: entity("301"), Name: "errand", Color: 41, ItemOrder: 2},
+			{Entity: entity("302"), Name: "waiting", Color: 42, ItemOrder: 3},
+		},
+		Filters: []todoist.Filter{
+			{Entity: entity("400"), Name: "Focus", Query: "(today | overdue) & p1", Color: 30, ItemOrder: 1},
+			{Entity: entity("401"), Name: "Waiting", Query: "@waiting", Color: 42, ItemOrder: 2},
+		},
+		Collaborators: []todoist.Collaborator{
+			{ID: "1", Email: "demo@example.com", FullName: "Demo User"},
+			{ID: "2", Email: "alice@example.com", FullName: "Alice Smith"},
+		},
+		CollaboratorStates: []todoist.CollaboratorState{
+			{ProjectID: "101", UserID: "1", State: "active"},
+			{ProjectID: "101", UserID: "2", State: "active"},
+		},
+	}
+
+	items := []struct {
+		id, project, section, parent todoist.ID
+		content                      string
+		priority                     int
+		due                          int
+		recurring                    string
+		labels                       []todoist.ID
+		checked                      bool
+	}{
+		{"500", "100", "", "", "Buy milk", 1, 0, "", []todoist.ID{"301"}, false},
+		{"501", "100", "", "", "Read the article about sync protocols", 1, noDue, "", nil, false},
+		{"502", "101", "201", "", "Write the quarterly report", 4, 0, "", []todoist.ID{"300"}, false},
+		{"503", "101", "201", "502", "Collect the numbers", 3, -1, "", nil, false},
+		{"504", "101", "200", "", "Answer the mail of Alice", 2, 1, "", []todoist.ID{"302"}, false},
+		{"505", "102", "", "", "Weekly planning", 3, 3, "every monday", nil, false},
+		{"506", "103", "", "", "Pay the rent", 4, -2, "every month", nil, false},
+		{"507", "103", "", "", "Fix the bike", 1, 5, "", []todoist.ID{"301"}, false},
+		{"508", "104", "", "", "Learn to play the guitar", 1, noDue, "", nil, false},
+		{"509", "101", "201", "", "Review the budget", 2, -1, "", nil, true},
+	}
+	for n, i := range items {
+		item := todoist.Item{
+			Entity:    entity(i.id),
+			UserID:    "1",
+			ProjectID: i.project,
+			SectionID: i.section,
+			ParentID:  i.parent,
+			Content:   i.content,
+			Priority:  i.priority,
+			Labels:    i.labels,
+			// older items come first
+			DateAdded:  todoist.Time{Time: today.AddDate(0, 0, -30+n)},
+			ChildOrder: n + 1,
+		}
+		if i.due != noDue {
+			item.Due.Date = day(i.due)
+			item.Due.String = item.Due.Date.Format("Jan 2")
+			if len(i.recurring) != 0 {
+				item.Due.IsRecurring = true
+				item.Due.String = i.recurring
+			}
+		}
+		// a block of the day and an errand without time for `todoist export timeblocks`
+		if i.id == "502" {
+			item.Due.Date = todoist.Time{Time: time.Date(now.Year(), now.Month(), now.Day(), 10, 0, 0, 0, time.Local).UTC()}
+			item.Duration = &todoist.ItemDuration{Amount: 120, Unit: "minute"}
+		}
+		if i.id == "500" {
+			item.Duration = &todoist.ItemDuration{Amount: 30, Unit: "minute"}
+		}
+		if i.id == "504" {
+			item.ResponsibleUID = "1"
+			item.AssignedByUID = "2"
+		}
+		if i.checked {
+			item.Checked = 1
+			item.CompletedDate = todoist.Time{Time: today.Add(-14 * time.Hour)}
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	state.Notes = []todoist.Note{
+		{Entity: entity("600"), PostedUID: "2", ItemID: "502", Content: "The template is in the shared drive.", Posted: day(-3)},
+		// locations of errands for `todoist nearby`
+		{Entity: entity("602"), PostedUID: "1", ItemID: "500", Content: "geo:35.6812,139.7671 Tokyo Station", Posted: day(-5)},
+		{Entity: entity("603"), PostedUID: "1", ItemID: "507", Content: "geo:35.6896,139.7006 Shinjuku", Posted: day(-4)},
+		// time tracked for `todoist report billing`
+		{Entity: entity("604"), PostedUID: "1", ItemID: "509", Content: "tracked 1h15m", Posted: day(-1)},
+	}
+	state.ProjectNotes = []todoist.Note{
+		{Entity: entity("601"), PostedUID: "1", ProjectID: "101", Content: "Everything for the day job.", Posted: day(-20)},
+	}
+	return state
+}
+
+// DemoEvents returns the additions of the items and the completions of the recurring items
+// of DemoState for the activity log.
+func DemoEvents(now time.Time) []todoist.Event {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	completions := []struct {
+		item, project todoist.ID
+		content       string
+		at            time.Time
+	}{
+		// weekly planning, due every monday: kept up recently, missed some before
+		{"505", "102", "Weekly planning", today.AddDate(0, 0, -4).Add(9 * time.Hour)},
+		{"505", "102", "Weekly planning", today.AddDate(0, 0, -10).Add(18 * time.Hour)},
+		{"505", "102", "Weekly planning", today.AddDate(0, 0, -25).Add(9 * time.Hour)},
+		{"505", "102", "Weekly planning", today.AddDate(0, 0, -46).Add(10 * time.Hour)},
+		// the rent, due every month: always paid, once late
+		{"506", "103", "Pay the rent", today.AddDate(0, -1, -3).Add(20 * time.Hour)},
+		{"506", "103", "Pay the rent", today.AddDate(0, -2, 1).Add(8 * time.Hour)},
+		{"506", "103", "Pay the rent", today.AddDate(0, -3, -2).Add(12 * time.Hour)},
+	}
+	var events []todoist.Event
+	for _, i := range DemoState(now).Items {
+		events = append(events, todoist.Event{
+			ID:              todoist.ID("7" + i.ID.String()),
+			ObjectType:      "item",
+			ObjectID:        i.ID,
+			EventType:       "added",
+			EventDate:       todoist.Time{Time: i.DateAdded.UTC()},
+			ParentProjectID: i.ProjectID,
+			ExtraData:       map[string]interface{}{"content": i.Content},
+		})
+	}
+	for n, c := range completions {
+		events = append(events, todoist.Event{
+			ID:              todoist.ID(strconv.Itoa(700 + n)),
+			ObjectType:      "item",
+			ObjectID:        c.item,
+			EventType:       "completed",
+			EventDate:       todoist.Time{Time: c.at.UTC()},
+			ParentProjectID: c.project,
+			ExtraData:       map[string]interface{}{"content": c.content},
+		})
+	}
+	return events
+}
@@ -0,0 +1,155 @@
+package todoisttest
+
+import (
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// GenerateOpts are the sizes of a generated account.
+type GenerateOpts struct {
+	Items    int
+	Projects int
+	// Labels is 20 by default.
+	Labels int
+	// Seed makes the same account for the same seed.
+	Seed int64
+}
+
+var (
+	verbs = []string{"Write", "Review", "Call", "Buy", "Fix", "Plan", "Read", "Clean", "Send", "Update",
+		"Prepare", "Book", "Check", "Schedule", "Draft", "Pay", "Order", "Cancel", "Research", "Organize"}
+	nouns = []string{"the report", "the budget", "the dentist", "groceries", "the bike", "the trip", "the article",
+		"the kitchen", "the invoice", "the roadmap", "the slides", "the tickets", "the backups", "the meeting",
+		"the proposal", "the rent", "the parts", "the subscription", "the options", "the photos"}
+	projectNames = []string{"Work", "Home", "Errands", "Side project", "Reading", "Health", "Finance", "Travel",
+		"Learning", "Garden", "Family", "Hiring", "Marketing", "Support", "Research"}
+	sectionNames = []string{"Triage", "Next", "In progress", "Waiting", "Done"}
+	recurrences  = []string{"every day", "every weekday", "every monday", "every week", "every 2 weeks", "every month"}
+)
+
+// Generate returns an account with synthetic data of the sizes, for benchmarks of syncs, caches and rendering.
+// Sizes follow what real accounts look like: a few projects hold most of the items, most items have no
+// label nor priority, about a third have no due date, overdue items trail into the past, some are
+// recurring, and some are sub tasks. Dates are relative to now, ids are sequential numbers.
+func Generate(opts GenerateOpts, now time.Time) todoist.SyncState {
+	r := rand.New(rand.NewSource(opts.Seed))
+	if opts.Projects < 1 {
+		opts.Projects = 1
+	}
+	if opts.Labels <= 0 {
+		opts.Labels = 20
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	next := int64(1000)
+	newID := func() todoist.ID {
+		next++
+		return todoist.ID(strconv.FormatInt(next, 10))
+	}
+
+	inbox := newID()
+	state := todoist.SyncState{
+		User: todoist.User{ID: "1", Email: "generated@example.com", FullName: "Generated User", InboxProjectID: inbox},
+		Projects: []todoist.Project{
+			{Entity: todoist.Entity{ID: inbox}, Name: "Inbox", Color: 48, InboxProject: true},
+		},
+	}
+	// a third of the projects are nested under an earlier one
+	for n := 1; n < opts.Projects; n++ {
+		p := todoist.Project{
+			Entity:     todoist.Entity{ID: newID()},
+			Name:       fmt.Sprintf("%s %d", projectNames[r.Intn(len(projectNames))], n),
+			Color:      30 + r.Intn(20),
+			ChildOrder: n,
+		}
+		if n > 1 && r.Intn(3) == 0 {
+			p.ParentID = state.Projects[1+r.Intn(n-1)].ID
+		}
+		state.Projects = append(state.Projects, p)
+	}
+	sections := map[todoist.ID][]todoist.ID{}
+	for _, p := range state.Projects[1:] {
+		count := r.Intn(len(sectionNames))
+		for n := 0; n < count; n++ {
+			s := todoist.Section{Entity: todoist.Entity{ID: newID()}, Name: sectionNames[n], ProjectID: p.ID, SectionOrder: n + 1}
+			state.Sections = append(state.Sections, s)
+			sections[p.ID] = append(sections[p.ID], s.ID)
+		}
+	}
+	for n := 0; n < opts.Labels; n++ {
+		state.Labels = append(state.Labels, todoist.Label{
+			Entity:    todoist.Entity{ID: newID()},
+			Name:      fmt.Sprintf("label%d", n+1),
+			Color:     30 + r.Intn(20),
+			ItemOrder: n + 1,
+		})
+	}
+
+	// projects and labels are picked by a zipf distribution, so that a few are used by most items
+	projectZipf := rand.NewZipf(r, 1.2, 1, uint64(len(state.Projects)-1))
+	labelZipf := rand.NewZipf(r, 1.5, 1, uint64(len(state.Labels)-1))
+	parents := map[todoist.ID][]todoist.Item{}
+	for n := 0; n < opts.Items; n++ {
+		p := state.Projects[projectZipf.Uint64()]
+		item := todoist.Item{
+			Entity:     todoist.Entity{ID: newID()},
+			UserID:     "1",
+			ProjectID:  p.ID,
+			Content:    fmt.Sprintf("%s %s", verbs[r.Intn(len(verbs))], nouns[r.Intn(len(nouns))]),
+			Priority:   1,
+			ChildOrder: n + 1,
+			// ages decay exponentially, two months on average
+			DateAdded: todoist.Time{Time: today.Add(-time.Duration(r.ExpFloat64() * 60 * 24 * float64(time.Hour)))},
+		}
+		switch x := r.Intn(100); {
+		case x < 5:
+			item.Priority = 4
+		case x < 15:
+			item.Priority = 3
+		case x < 30:
+			item.Priority = 2
+		}
+		if ss := sections[p.ID]; len(ss) > 0 && r.Intn(2) == 0 {
+			item.SectionID = ss[r.Intn(len(ss))]
+		}
+		if ps := parents[p.ID]; len(ps) > 0 && r.Intn(100) < 15 {
+			parent := ps[r.Intn(len(ps))]
+			item.ParentID = parent.ID
+			item.SectionID = parent.SectionID
+		}
+		for x := r.Intn(100); x >= 60; x -= 30 {
+			if id := state.Labels[labelZipf.Uint64()].ID; !hasID(item.Labels, id) {
+				item.Labels = append(item.Labels, id)
+			}
+		}
+		if r.Intn(3) != 0 {
+			// mostly soon, with a tail of overdue items
+			offset := int(r.NormFloat64() * 10)
+			if r.Intn(5) == 0 {
+				offset = -int(r.ExpFloat64() * 30)
+			}
+			item.Due.Date = todoist.Time{Time: today.AddDate(0, 0, offset)}
+			item.Due.String = item.Due.Date.Format("Jan 2")
+			if r.Intn(10) == 0 {
+				item.Due.IsRecurring = true
+				item.Due.String = recurrences[r.Intn(len(recurrences))]
+			}
+		}
+		state.Items = append(state.Items, item)
+		if item.ParentID.IsZero() {
+			parents[p.ID] = append(parents[p.ID], item)
+		}
+	}
+	return state
+}
+
+func hasID(ids []todoist.ID, id todoist.ID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,583 @@
+// Package todoisttest provides a fake todoist server which keeps its state in memory,
+// for tests and the demo of the command line tool.
+package todoisttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// resourceKeys maps the resource of a command type, such as `item` of `item_add`,
+// to the key of the sync response.
+var resourceKeys = map[string]string{
+	"project":  "projects",
+	"item":     "items",
+	"note":     "notes",
+	"label":    "labels",
+	"filter":   "filters",
+	"reminder": "reminders",
+	"section":  "sections",
+}
+
+var listKeys = []string{
+	"projects", "project_notes", "items", "notes", "labels", "filters",
+	"reminders", "sections", "collaborators", "collaborator_states",
+}
+
+type resource map[string]interface{}
+
+type command struct {
+	Type   string   `json:"type"`
+	Args   resource `json:"args"`
+	UUID   string   `json:"uuid"`
+	TempID string   `json:"temp_id"`
+}
+
+// Server is a httptest.Server answering the sync api v8 from a state in memory.
+// Commands of the sync endpoint change the state. A sync with the token `*` returns all of it,
+// and a sync with an earlier token returns the resources changed since then.
+// Deleted resources are kept with is_deleted, so that caches of clients drop them.
+type Server struct {
+	*httptest.Server
+	mu        sync.Mutex
+	user      resource
+	resources map[string][]resource
+	tempIDs   map[string]json.Number
+	// changed is the sync count when a resource, keyed by `key/id`, changed last.
+	changed   map[string]int
+	events    []todoist.Event
+	nextID    int64
+	syncCount int
+	// Now returns the time of added and completed resources. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewServer starts a server with the state. Close it when finished.
+func NewServer(state todoist.SyncState) (*Server, error) {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	s := &Server{
+		resources: map[string][]resource{},
+		tempIDs:   map[string]json.Number{},
+		changed:   map[string]int{},
+		nextID:    1,
+		Now:       time.Now,
+	}
+	if err = decode(raw["user"], &s.user); err != nil {
+		return nil, err
+	}
+	for _, key := range listKeys {
+		var list []resource
+		if err = decode(raw[key], &list); err != nil {
+			return nil, err
+		}
+		for _, r := range list {
+			if id, err := strconv.ParseInt(fmt.Sprint(r["id"]), 10, 64); err == nil && id >= s.nextID {
+				s.nextID = id + 1
+			}
+		}
+		s.resources[key] = list
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sync/v8/sync", s.handleSync)
+	mux.HandleFunc("/sync/v8/completed/get_all", s.handleCompleted)
+	mux.HandleFunc("/sync/v8/archive/items", s.handleArchive)
+	mux.HandleFunc("/sync/v8/items/get", s.handleItemGet)
+	mux.HandleFunc("/sync/v8/activity/get", s.handleActivity)
+	mux.HandleFunc("/sync/v8/uploads/add", s.handleUpload)
+	s.Server = httptest.NewServer(s.authorize(mux))
+	return s, nil
+}
+
+// Endpoint is the endpoint to give todoist.NewClient.
+func (s *Server) Endpoint() string {
+	return s.URL + "/sync/v8"
+}
+
+// AddEvents adds events to the activity log. The commands of items add their events as well.
+func (s *Server) AddEvents(events ...todoist.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+}
+
+func (s *Server) addItemEvent(eventType string, item resource, now time.Time) {
+	e := todoist.Event{
+		ID:         todoist.ID(s.newID()),
+		ObjectType: "item",
+		ObjectID:   todoist.ID(fmt.Sprint(item["id"])),
+		EventType:  eventType,
+		EventDate:  todoist.Time{Time: now},
+		ExtraData:  map[string]interface{}{"content": item["content"]},
+	}
+	if p := fmt.Sprint(item["project_id"]); !isZeroID(item["project_id"]) {
+		e.ParentProjectID = todoist.ID(p)
+	}
+	s.events = append(s.events, e)
+}
+
+// decode keeps numbers as they are, so that ids are written back without change.
+func decode(b []byte, v interface{}) error {
+	if len(b) == 0 || string(b) == "null" {
+		return nil
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func (s *Server) authorize(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.FormValue("token")) == 0 {
+			http.Error(w, "missing token", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	var commands []command
+	if err := decode([]byte(r.FormValue("commands")), &commands); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := map[string]interface{}{}
+	mapping := map[string]json.Number{}
+	for _, c := range commands {
+		if err := s.apply(c); err != nil {
+			status[c.UUID] = map[string]string{"error": err.Error()}
+			continue
+		}
+		status[c.UUID] = "ok"
+		if id, ok := s.tempIDs[c.TempID]; ok {
+			mapping[c.TempID] = id
+		}
+	}
+	since, err := strconv.Atoi(r.FormValue("sync_token"))
+	full := err != nil
+	var types []string
+	if err = json.Unmarshal([]byte(r.FormValue("resource_types")), &types); err != nil {
+		types = []string{"all"}
+	}
+	s.syncCount++
+	res := map[string]interface{}{
+		"sync_token":      strconv.Itoa(s.syncCount),
+		"full_sync":       full,
+		"sync_status":     status,
+		"temp_id_mapping": mapping,
+	}
+	if syncs(types, "user") {
+		res["user"] = s.user
+	}
+	for key, list := range s.resources {
+		if !syncs(types, key) {
+			continue
+		}
+		changed := []resource{}
+		for _, r := range list {
+			if full || s.changed[key+"/"+fmt.Sprint(r["id"])] > since {
+				changed = append(changed, r)
+			}
+		}
+		res[key] = changed
+	}
+	writeJSON(w, res)
+}
+
+// syncs reports whether the resource types of a sync request, such as `["all", "-notes"]`, have the key.
+func syncs(types []string, key string) bool {
+	res := false
+	for _, t := range types {
+		if t == "-"+key {
+			return false
+		}
+		if t == "all" || t == key {
+			res = true
+		}
+	}
+	return res
+}
+
+// touch marks the resource changed by the sync in progress.
+func (s *Server) touch(key string, r resource) {
+	s.changed[key+"/"+fmt.Sprint(r["id"])] = s.syncCount + 1
+}
+
+func (s *Server) newID() json.Number {
+	id := json.Number(strconv.FormatInt(s.nextID, 10))
+	s.nextID++
+	return id
+}
+
+// resolveTempIDs replaces temp ids of the args, including those in lists such as labels.
+func (s *Server) resolveTempIDs(args resource) {
+	for k, v := range args {
+		switch v := v.(type) {
+		case string:
+			if id, ok := s.tempIDs[v]; ok {
+				args[k] = id
+			}
+		case []interface{}:
+			for i, e := range v {
+				if str, ok := e.(string); ok {
+					if id, ok := s.tempIDs[str]; ok {
+						v[i] = id
+					}
+				}
+			}
+		}
+	}
+}
+
+func (s *Server) find(key string, id interface{}) resource {
+	for _, r := range s.resources[key] {
+		if fmt.Sprint(r["id"]) == fmt.Sprint(id) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (s *Server) apply(c command) error {
+	i := strings.Index(c.Type, "_")
+	if i < 0 {
+		return fmt.Errorf("unknown command: %s", c.Type)
+	}
+	key, ok := resourceKeys[c.Type[:i]]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", c.Type)
+	}
+	action := c.Type[i+1:]
+	if c.Args == nil {
+		c.Args = resource{}
+	}
+	s.resolveTempIDs(c.Args)
+	if key == "notes" && isZeroID(c.Args["item_id"]) {
+		key = "project_notes"
+	}
+	now := s.Now().UTC()
+
+	if action == "add" {
+		id := s.newID()
+		if len(c.TempID) != 0 {
+			s.tempIDs[c.TempID] = id
+		}
+		c.Args["id"] = id
+		c.Args["is_deleted"] = 0
+		c.Args["date_added"] = now.Format(time.RFC3339)
+		if key == "items" {
+			// like the real server, items without a project go to the inbox
+			if isZeroID(c.Args["project_id"]) {
+				c.Args["project_id"] = s.user["inbox_project"]
+			}
+			c.Args["checked"] = 0
+			resolveDue(c.Args, now)
+		}
+		s.resources[key] = append(s.resources[key], c.Args)
+		s.touch(key, c.Args)
+		if key == "items" {
+			s.addItemEvent("added", c.Args, now)
+		}
+		return nil
+	}
+	switch action {
+	case "reorder", "update_orders", "update_orders_v2", "update_day_orders":
+		// orders are not kept
+		return nil
+	}
+	if key == "notes" {
+		if r := s.find("project_notes", c.Args["id"]); r != nil {
+			key = "project_notes"
+		}
+	}
+	r := s.find(key, c.Args["id"])
+	if r == nil {
+		return fmt.Errorf("no such %s: %v", c.Type[:i], c.Args["id"])
+	}
+	s.touch(key, r)
+	if key == "items" {
+		switch action {
+		case "complete", "close":
+			s.addItemEvent("completed", r, now)
+		case "uncomplete":
+			s.addItemEvent("uncompleted", r, now)
+		case "delete":
+			s.addItemEvent("deleted", r, now)
+		default:
+			s.addItemEvent("updated", r, now)
+		}
+	}
+	switch action {
+	case "delete":
+		r["is_deleted"] = 1
+	case "complete", "close":
+		r["checked"] = 1
+		date := now.Format(time.RFC3339)
+		if d, ok := c.Args["date_completed"].(string); ok && len(d) != 0 {
+			date = d
+		}
+		r["completed_date"] = date
+	case "uncomplete":
+		r["checked"] = 0
+		r["completed_date"] = nil
+	case "archive":
+		r["is_archived"] = 1
+	case "unarchive":
+		r["is_archived"] = 0
+	default:
+		// update, move and the like change the fields given
+		for k, v := range c.Args {
+			if k != "id" {
+				r[k] = v
+			}
+		}
+		if key == "items" {
+			resolveDue(r, now)
+		}
+	}
+	return nil
+}
+
+func isZeroID(v interface{}) bool {
+	s := fmt.Sprint(v)
+	return v == nil || s == "" || s == "0"
+}
+
+// resolveDue fills the date of a due given only by its string. Unlike the real
+// server, it understands just `today`, `tomorrow` and dates like 2019-03-10.
+func resolveDue(item resource, now time.Time) {
+	due, ok := item["due"].(map[string]interface{})
+	if !ok || due["date"] != nil {
+		return
+	}
+	str, _ := due["string"].(string)
+	var date time.Time
+	switch strings.ToLower(str) {
+	case "today":
+		date = now.Local()
+	case "tomorrow":
+		date = now.Local().AddDate(0, 0, 1)
+	default:
+		t, err := time.Parse("2006-01-02", str)
+		if err != nil {
+			return
+		}
+		date = t
+	}
+	due["date"] = date.Format("2006-01-02")
+}
+
+func (s *Server) completedItems(r *http.Request) []resource {
+	var res []resource
+	for _, item := range s.resources["items"] {
+		if fmt.Sprint(item["checked"]) != "1" || fmt.Sprint(item["is_deleted"]) == "1" {
+			continue
+		}
+		if p := r.FormValue("project_id"); len(p) != 0 && fmt.Sprint(item["project_id"]) != p {
+			continue
+		}
+		res = append(res, item)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return fmt.Sprint(res[i]["completed_date"]) > fmt.Sprint(res[j]["completed_date"])
+	})
+	return res
+}
+
+func (s *Server) handleCompleted(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	const layout = "2006-01-02T15:04"
+	since, _ := time.Parse(layout, r.FormValue("since"))
+	until, _ := time.Parse(layout, r.FormValue("until"))
+	var items []resource
+	projects := map[string]resource{}
+	for _, item := range s.completedItems(r) {
+		completed, _ := time.Parse(time.RFC3339, fmt.Sprint(item["completed_date"]))
+		if (!since.IsZero() && completed.Before(since)) || (!until.IsZero() && completed.After(until)) {
+			continue
+		}
+		completedItem := resource{
+			"id":             item["id"],
+			"task_id":        item["id"],
+			"content":        item["content"],
+			"project_id":     item["project_id"],
+			"completed_date": item["completed_date"],
+		}
+		if r.FormValue("annotate_notes") == "true" {
+			notes := []resource{}
+			for _, n := range s.resources["notes"] {
+				if fmt.Sprint(n["item_id"]) == fmt.Sprint(item["id"]) && fmt.Sprint(n["is_deleted"]) != "1" {
+					notes = append(notes, n)
+				}
+			}
+			completedItem["notes"] = notes
+		}
+		if r.FormValue("annotate_items") == "true" {
+			completedItem["item_object"] = item
+		}
+		items = append(items, completedItem)
+		if p := s.find("projects", item["project_id"]); p != nil {
+			projects[fmt.Sprint(item["project_id"])] = p
+		}
+	}
+	offset, _ := strconv.Atoi(r.FormValue("offset"))
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil || limit <= 0 {
+		limit = 30
+	}
+	items = page(items, offset, limit)
+	if items == nil {
+		items = []resource{}
+	}
+	writeJSON(w, map[string]interface{}{"items": items, "projects": projects})
+}
+
+func page(items []resource, offset, limit int) []resource {
+	if offset > len(items) {
+		offset = len(items)
+	}
+	if offset+limit < len(items) {
+		return items[offset : offset+limit]
+	}
+	return items[offset:]
+}
+
+func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []resource
+	for _, item := range s.completedItems(r) {
+		if p := r.FormValue("section_id"); len(p) != 0 && fmt.Sprint(item["section_id"]) != p {
+			continue
+		}
+		if p := r.FormValue("parent_id"); len(p) != 0 && fmt.Sprint(item["parent_id"]) != p {
+			continue
+		}
+		items = append(items, item)
+	}
+	offset, _ := strconv.Atoi(r.FormValue("cursor"))
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil || limit <= 0 {
+		limit = 30
+	}
+	res := map[string]interface{}{"items": page(items, offset, limit), "has_more": offset+limit < len(items)}
+	if offset+limit < len(items) {
+		res["next_cursor"] = strconv.Itoa(offset + limit)
+	}
+	writeJSON(w, res)
+}
+
+func (s *Server) handleItemGet(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := s.find("items", r.FormValue("item_id"))
+	if item == nil {
+		http.Error(w, "item not found", http.StatusNotFound)
+		return
+	}
+	var notes []resource
+	for _, n := range s.resources["notes"] {
+		if fmt.Sprint(n["item_id"]) == fmt.Sprint(item["id"]) {
+			notes = append(notes, n)
+		}
+	}
+	writeJSON(w, map[string]interface{}{
+		"item":    item,
+		"project": s.find("projects", item["project_id"]),
+		"notes":   notes,
+	})
+}
+
+// handleUpload answers the attachment of an uploaded file. The file itself is not kept.
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer f.Close()
+	size, err := io.Copy(ioutil.Discard, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.FormValue("file_name")
+	if len(name) == 0 {
+		name = header.Filename
+	}
+	writeJSON(w, todoist.FileAttachment{
+		FileName:    name,
+		FileSize:    int(size),
+		FileType:    header.Header.Get("Content-Type"),
+		FileURL:     fmt.Sprintf("%s/uploads/%s/%s", s.URL, s.newID(), url.PathEscape(name)),
+		UploadState: "completed",
+	})
+}
+
+func (s *Server) handleActivity(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	const layout = "2006-01-02T15:04"
+	since, _ := time.Parse(layout, r.FormValue("since"))
+	until, _ := time.Parse(layout, r.FormValue("until"))
+	var events []todoist.Event
+	for _, e := range s.events {
+		switch {
+		case len(r.FormValue("object_type")) != 0 && e.ObjectType != r.FormValue("object_type"),
+			len(r.FormValue("object_id")) != 0 && e.ObjectID.String() != r.FormValue("object_id"),
+			len(r.FormValue("event_type")) != 0 && e.EventType != r.FormValue("event_type"),
+			len(r.FormValue("parent_project_id")) != 0 && e.ParentProjectID.String() != r.FormValue("parent_project_id"),
+			!since.IsZero() && e.EventDate.Before(todoist.Time{Time: since}),
+			!until.IsZero() && e.EventDate.After(todoist.Time{Time: until}):
+			continue
+		}
+		events = append(events, e)
+	}
+	// the latest first
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].EventDate.After(events[j].EventDate)
+	})
+	count := len(events)
+	offset, _ := strconv.Atoi(r.FormValue("offset"))
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil || limit <= 0 {
+		limit = 30
+	}
+	if offset > len(events) {
+		offset = len(events)
+	}
+	events = events[offset:]
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	writeJSON(w, todoist.ActivityEvents{Events: events, Count: count})
+}
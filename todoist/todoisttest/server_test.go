@@ -0,0 +1,426 @@
+package todoisttest
+
+import (
+	"context"
+	"fmt"
+	"github.com/kobtea/go-todoist/todoist"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient serves the state by a fake server and returns a client of it with a temp cache directory.
+// The returned func closes the server and removes the directory.
+func newTestClient(t *testing.T, state todoist.SyncState) (*Server, *todoist.Client, func()) {
+	t.Helper()
+	server, err := NewServer(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "todoisttest")
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	cleanup := func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}
+	client, err := todoist.NewClient(server.Endpoint(), "token", "*", dir, nil)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return server, client, cleanup
+}
+
+func TestServer(t *testing.T) {
+	_, client, cleanup := newTestClient(t, DemoState(time.Now()))
+	defer cleanup()
+	ctx := context.Background()
+	if err := client.FullSync(ctx, []todoist.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	if user := client.User.Get(); user == nil || user.FullName != "Demo User" {
+		t.Errorf("expect the demo user, but got %v", user)
+	}
+	if n := len(client.Item.GetAll()); n != 10 {
+		t.Errorf("expect 10 items, but got %d", n)
+	}
+
+	item := todoist.Item{Content: "new item"}
+	item.Due.String = "tomorrow"
+	added, err := client.Item.Add(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// the item of the temp id is replaced by the item of the real id
+	items := client.Item.FindByContent("new item")
+	if len(items) != 1 || items[0].ID == added.ID {
+		t.Fatalf("expect the added item, but got %v", items)
+	}
+	if items[0].ProjectID != "100" {
+		t.Errorf("expect the item in the inbox, but got project %s", items[0].ProjectID)
+	}
+	if items[0].Due.Date.IsZero() {
+		t.Error("expect the due date of tomorrow, but got no date")
+	}
+
+	if err = client.Item.Complete(items[0].ID, todoist.Time{Time: time.Now().UTC()}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Item.Delete("507"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if i := client.Item.Resolve(items[0].ID); i == nil || !i.IsChecked() {
+		t.Errorf("expect the item completed, but got %v", i)
+	}
+	if i := client.Item.Resolve("507"); i != nil {
+		t.Errorf("expect the item deleted, but got %v", i)
+	}
+	completed, err := client.Completed.GetAllWithOpts(ctx, &todoist.CompletedGetAllOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(completed.Items) != 2 || completed.Items[0].Content != "new item" {
+		t.Errorf("expect the completed items, but got %v", completed.Items)
+	}
+	completed, err = client.Completed.GetAllWithOpts(ctx, &todoist.CompletedGetAllOpts{AnnotateItems: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o := completed.Items[0].ItemObject; o == nil || o.ID != completed.Items[0].TaskID {
+		t.Errorf("expect the item object of the completed item, but got %v", o)
+	}
+}
+
+func TestIncrementalSync(t *testing.T) {
+	server, client, cleanup := newTestClient(t, DemoState(time.Now()))
+	defer cleanup()
+	dir := client.CacheDir
+	ctx := context.Background()
+	if err := client.FullSync(ctx, []todoist.Command{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a new client reads the cache and syncs only the changes
+	client, err := todoist.NewClient(server.Endpoint(), "token", "*", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Incremental = true
+	client.ResourceTypes = []string{"all", "-notes"}
+	if _, err = client.Item.Add(todoist.Item{Content: "incremental item"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Item.Delete("507"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err = todoist.NewClient(server.Endpoint(), "token", "*", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(client.Item.GetAll()); n != 10 {
+		t.Errorf("expect 10 items in the cache, but got %d", n)
+	}
+	if items := client.Item.FindByContent("incremental item"); len(items) != 1 {
+		t.Errorf("expect the added item in the cache, but got %v", items)
+	}
+	if i := client.Item.Resolve("507"); i != nil {
+		t.Errorf("expect the deleted item dropped from the cache, but got %v", i)
+	}
+	if n := len(client.Project.GetAll()); n != 5 {
+		t.Errorf("expect 5 projects in the cache, but got %d", n)
+	}
+	if n := len(client.Note.GetAllForItem("500")); n == 0 {
+		t.Error("expect the excluded notes kept in the cache, but got none")
+	}
+}
+
+func TestPagers(t *testing.T) {
+	now := time.Now()
+	server, client, cleanup := newTestClient(t, DemoState(now))
+	defer cleanup()
+	server.AddEvents(DemoEvents(now)...)
+	ctx := context.Background()
+
+	all, err := client.Activity.GetAllPages(ctx, &todoist.ActivityGetOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := client.Activity.Pager(&todoist.ActivityGetOpts{Limit: 2})
+	n := 0
+	for events.Next(ctx) {
+		if len(events.Events()) > 2 {
+			t.Errorf("expect at most 2 events in a page, but got %d", len(events.Events()))
+		}
+		n += len(events.Events())
+	}
+	if err = events.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(all) || n == 0 {
+		t.Errorf("expect %d events, but got %d", len(all), n)
+	}
+	if info := events.PageInfo(); info.HasMore || info.Offset != n || info.Page < n/2 {
+		t.Errorf("unexpected page info: %+v", info)
+	}
+
+	completed := client.Completed.Pager(&todoist.CompletedGetAllOpts{Limit: 1})
+	n = 0
+	for completed.Next(ctx) {
+		n += len(completed.Items().Items)
+	}
+	if err = completed.Err(); err != nil || n != 1 {
+		t.Errorf("expect 1 completed item, but got %d, %v", n, err)
+	}
+
+	archived := client.Archive.Pager(&todoist.ArchiveItemsOpts{})
+	if archived.Next(ctx) || archived.Err() == nil {
+		t.Error("expect an error without a project")
+	}
+	archived = client.Archive.Pager(&todoist.ArchiveItemsOpts{ProjectID: "101", Limit: 1})
+	n = 0
+	for archived.Next(ctx) {
+		n += len(archived.Items())
+	}
+	if err = archived.Err(); err != nil || n != 1 {
+		t.Errorf("expect 1 archived item, but got %d, %v", n, err)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	_, client, cleanup := newTestClient(t, DemoState(time.Now()))
+	defer cleanup()
+	ctx := context.Background()
+	attachment, err := client.Upload.Add(ctx, "notes.txt", "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.FileName != "notes.txt" || attachment.FileSize != 5 || attachment.FileType != "text/plain" || len(attachment.FileURL) == 0 {
+		t.Errorf("unexpected attachment: %+v", attachment)
+	}
+	note, err := todoist.NewNote("500", "see the file", &todoist.NewNoteOpts{FileAttachment: *attachment})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Note.Add(*note); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.FullSync(ctx, []todoist.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, n := range client.Note.GetAllForItem("500") {
+		found = found || n.FileAttachment.FileURL == attachment.FileURL
+	}
+	if !found {
+		t.Error("expect the note with the attachment")
+	}
+}
+
+func TestPendingCommands(t *testing.T) {
+	server, client, cleanup := newTestClient(t, DemoState(time.Now()))
+	defer cleanup()
+	dir := client.CacheDir
+	down, err := NewServer(DemoState(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	down.Close()
+
+	// a commit failing on the network keeps its commands
+	client, err = todoist.NewClient(down.Endpoint(), "token", "*", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err = client.Item.Add(todoist.Item{Content: "pending item"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Commit(ctx); err == nil {
+		t.Fatal("expect error, but no error")
+	}
+	p, err := client.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p == nil || len(p.Commands) != 1 {
+		t.Fatalf("expect 1 pending command, but got %v", p)
+	}
+
+	// the next run retries them
+	client, err = todoist.NewClient(server.Endpoint(), "token", "*", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.RetryPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if p, err = client.Pending(); err != nil || p != nil {
+		t.Errorf("expect no pending commands after the retry, but got %v (%v)", p, err)
+	}
+	if items := client.Item.FindByContent("pending item"); len(items) != 1 {
+		t.Errorf("expect the retried item in the cache, but got %v", items)
+	}
+
+	// commands which the server rejects are dropped instead of retried on every run
+	client, err = todoist.NewClient(down.Endpoint(), "token", "*", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Item.Add(todoist.Item{Content: "rejected item"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Commit(ctx); err == nil {
+		t.Fatal("expect error, but no error")
+	}
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer rejecting.Close()
+	client, err = todoist.NewClient(rejecting.URL, "token", "*", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.RetryPending(ctx); err == nil {
+		t.Fatal("expect error, but no error")
+	}
+	if p, err = client.Pending(); err != nil || p != nil {
+		t.Errorf("expect no pending commands after a rejected retry, but got %v (%v)", p, err)
+	}
+}
+
+func TestBatchExecute(t *testing.T) {
+	_, client, cleanup := newTestClient(t, DemoState(time.Now()))
+	defer cleanup()
+	ctx := context.Background()
+	if err := client.FullSync(ctx, []todoist.Command{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var projectID todoist.ID
+	ops := []todoist.Operation{func(c *todoist.Client) error {
+		p, err := c.Project.Add(todoist.Project{Entity: todoist.Entity{ID: todoist.GenerateTempID()}, Name: "Batch"})
+		if err == nil {
+			projectID = p.ID
+		}
+		return err
+	}}
+	for i := 0; i < 150; i++ {
+		content := fmt.Sprintf("batch item %d", i)
+		ops = append(ops, func(c *todoist.Client) error {
+			_, err := c.Item.Add(todoist.Item{Content: content, ProjectID: projectID})
+			return err
+		})
+	}
+	ops = append(ops, func(c *todoist.Client) error {
+		return c.Item.Close("999999")
+	})
+	var progress []int
+	results, err := client.BatchExecute(ctx, ops, func(done, total int) {
+		if total != len(ops) {
+			t.Errorf("expect total %d, but got %d", len(ops), total)
+		}
+		progress = append(progress, done)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 2 || progress[0] != 100 || progress[1] != len(ops) {
+		t.Errorf("expect the progress of 2 requests, but got %v", progress)
+	}
+	for i, r := range results[:len(ops)-1] {
+		if r.Err != nil || len(r.Commands) != 1 {
+			t.Errorf("expect operation %d ok, but got %v", i, r)
+		}
+	}
+	if r := results[len(ops)-1]; r.Err == nil {
+		t.Error("expect the error of the unknown item, but got no error")
+	}
+	var project *todoist.Project
+	for _, p := range client.Project.GetAll() {
+		if p.Name == "Batch" {
+			project = &p
+		}
+	}
+	if project == nil || todoist.IsTempID(project.ID) {
+		t.Fatalf("expect the added project, but got %v", project)
+	}
+	for _, content := range []string{"batch item 0", "batch item 149"} {
+		items := client.Item.FindByContent(content)
+		if len(items) != 1 || items[0].ProjectID != project.ID {
+			t.Errorf("expect %s in the added project, but got %v", content, items)
+		}
+	}
+
+	// a failed request is the error of every operation which was not committed
+	down, err := NewServer(DemoState(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	down.Close()
+	client, err = todoist.NewClient(down.Endpoint(), "token", "*", client.CacheDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err = client.BatchExecute(ctx, ops[1:3], nil)
+	if err == nil {
+		t.Fatal("expect error, but no error")
+	}
+	for i, r := range results {
+		if r.Err != err {
+			t.Errorf("expect the error of the request for operation %d, but got %v", i, r.Err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	now := time.Now()
+	state := Generate(GenerateOpts{Items: 1000, Projects: 20, Seed: 1}, now)
+	if len(state.Items) != 1000 || len(state.Projects) != 20 || len(state.Labels) != 20 {
+		t.Fatalf("expect 1000 items, 20 projects and 20 labels, but got %d, %d and %d",
+			len(state.Items), len(state.Projects), len(state.Labels))
+	}
+	again := Generate(GenerateOpts{Items: 1000, Projects: 20, Seed: 1}, now)
+	if changes := todoist.DiffStates(state, again); !changes.IsEmpty() {
+		t.Errorf("expect the same account for the same seed, but got %v", changes)
+	}
+
+	_, client, cleanup := newTestClient(t, state)
+	defer cleanup()
+	if err := client.FullSync(context.Background(), []todoist.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(client.Item.GetAll()); n != 1000 {
+		t.Errorf("expect 1000 synced items, but got %d", n)
+	}
+	for _, i := range client.Item.GetAll() {
+		if client.Project.Resolve(i.ProjectID) == nil {
+			t.Fatalf("expect the project of %s, but got none", i.ID)
+		}
+		if !i.ParentID.IsZero() && client.Item.Resolve(i.ParentID) == nil {
+			t.Fatalf("expect the parent of %s, but got none", i.ID)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path"
+)
+
+// UploadClient uploads files to attach them to notes.
+type UploadClient struct {
+	*Client
+}
+
+// Add uploads the content of r as the file name, and returns the attachment for a new note.
+func (c *UploadClient) Add(ctx context.Context, name, contentType string, r io.Reader) (*FileAttachment, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("token", c.Token); err != nil {
+		return nil, err
+	}
+	if err := w.WriteField("file_name", name); err != nil {
+		return nil, err
+	}
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, name))
+	header.Set("Content-Type", contentType)
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(part, r); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	u := *c.URL
+	u.Path = path.Join(c.URL.Path, "uploads/add")
+	req, err := http.NewRequest(http.MethodPost, u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	res, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if (res.StatusCode / 100) != 2 {
+		res.Body.Close()
+		return nil, fmt.Errorf("failed to upload %s: %s", name, res.Status)
+	}
+	var out FileAttachment
+	if err = decodeBody(res, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
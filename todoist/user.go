@@ -0,0 +1,54 @@
+package todoist
+
+type User struct {
+	ID             ID     `json:"id"`
+	Email          string `json:"email"`
+	FullName       string `json:"full_name"`
+	InboxProjectID ID     `json:"inbox_project"`
+	Timezone       string `json:"timezone"`
+	TzInfo         struct {
+		Timezone  string `json:"timezone"`
+		GmtString string `json:"gmt_string"`
+		Hours     int    `json:"hours"`
+		Minutes   int    `json:"minutes"`
+		IsDst     int    `json:"is_dst"`
+	} `json:"tz_info"`
+	IsPremium IntBool `json:"is_premium"`
+}
+
+func (u User) String() string {
+	return u.FullName
+}
+
+func (u User) ColorString() string {
+	return u.String()
+}
+
+type UserClient struct {
+	*Client
+	cache *userCache
+}
+
+// Get returns the user of the token, or nil before the first sync.
+func (c UserClient) Get() *User {
+	user := c.cache.get()
+	if user.ID.IsZero() {
+		return nil
+	}
+	return &user
+}
+
+type userCache struct {
+	user *User
+	lazy *lazyCache
+}
+
+func (c *userCache) get() User {
+	c.lazy.ensure()
+	return *c.user
+}
+
+func (c *userCache) store(user User) {
+	c.lazy.ensure()
+	c.user = &user
+}